@@ -0,0 +1,158 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+)
+
+// Achievement is an achievement of a player displayed by Achievements.
+type Achievement struct {
+	// ID is the ID of the achievement, passed to the AchievementProvider when it is claimed.
+	ID string
+	// Name is the name of the achievement. It may contain Minecraft formatting codes.
+	Name string
+	// Description is the description of the achievement. It may contain Minecraft formatting codes.
+	Description string
+	// Category is the category of the achievement. Achievements without category are listed under 'General'.
+	Category string
+	// Progress is the progress made by the player towards the Goal of the achievement.
+	Progress int
+	// Goal is the progress needed to complete the achievement. If zero, the achievement is complete.
+	Goal int
+	// Claimed specifies if the reward of the achievement was already claimed by the player.
+	Claimed bool
+}
+
+// Completed checks if the Progress of the achievement has reached its Goal.
+func (a Achievement) Completed() bool {
+	return a.Progress >= a.Goal
+}
+
+// AchievementProvider provides the achievements of players and claims their rewards.
+type AchievementProvider interface {
+	// Achievements returns the achievements of the player passed.
+	Achievements(submitter form.Submitter) []Achievement
+	// Claim claims the reward of the completed achievement with the ID passed for the player passed. If it returns an
+	// error, the error is displayed to the player.
+	Claim(submitter form.Submitter, id string) error
+}
+
+// Achievements represents a series of menus displaying the achievements of a player by category. Every achievement is
+// displayed with a progress bar, and completed achievements of which the reward was not yet claimed have a button to
+// claim it.
+type Achievements struct {
+	// Title is the title of the forms.
+	Title string
+	// Provider provides the achievements displayed and claims their rewards.
+	Provider AchievementProvider
+	// PageSize is the amount of achievements displayed per page. If zero, 5 achievements are displayed per page.
+	PageSize int
+}
+
+// Send sends the category menu of the achievements to the submitter passed.
+func (a Achievements) Send(submitter form.Submitter) {
+	submitter.SendForm(a.categories(submitter))
+}
+
+// categories returns the menu listing the categories of the achievements of the submitter passed.
+func (a Achievements) categories(submitter form.Submitter) *Menu {
+	achievements := a.Provider.Achievements(submitter)
+	completed := make(map[string]int)
+	total := make(map[string]int)
+	var categories []string
+	for _, achievement := range achievements {
+		category := categoryOf(achievement)
+		if total[category] == 0 {
+			categories = append(categories, category)
+		}
+		total[category]++
+		if achievement.Completed() {
+			completed[category]++
+		}
+	}
+	sort.Strings(categories)
+
+	var done int
+	for _, n := range completed {
+		done += n
+	}
+	m := NewMenu(a.Title, fmt.Sprintf("%v/%v achievements completed.\n%v", done, len(achievements),
+		ProgressBar(float64(done), float64(len(achievements)), 30)))
+	for _, category := range categories {
+		category := category
+		m.AddButtons(Button{Text: fmt.Sprintf("%v\n§8%v/%v completed", category, completed[category], total[category]),
+			Submit: func() {
+				submitter.SendForm(a.category(submitter, category, 0, ""))
+			},
+		})
+	}
+	return m
+}
+
+// category returns the menu displaying the page passed of the achievements in the category passed, with the error
+// passed displayed under the content.
+func (a Achievements) category(submitter form.Submitter, category string, page int, err string) *Menu {
+	var achievements []Achievement
+	for _, achievement := range a.Provider.Achievements(submitter) {
+		if categoryOf(achievement) == category {
+			achievements = append(achievements, achievement)
+		}
+	}
+	size := a.PageSize
+	if size <= 0 {
+		size = 5
+	}
+	start, end, pages := pageBounds(len(achievements), page, size)
+	content := fmt.Sprintf("%v (page %v/%v)", category, start/size+1, pages)
+	if err != "" {
+		content += "\n\n§c" + err
+	}
+	m := NewMenu(a.Title, content)
+	for _, achievement := range achievements[start:end] {
+		achievement := achievement
+		status := "§7Locked"
+		if achievement.Claimed {
+			status = "§aClaimed"
+		} else if achievement.Completed() {
+			status = "§6Completed"
+		}
+		m.Elements = append(m.Elements,
+			Header{Text: achievement.Name},
+			Label{Text: fmt.Sprintf("%v\n%v %v/%v §8- %v", achievement.Description,
+				ProgressBar(float64(achievement.Progress), float64(achievement.Goal), 20), achievement.Progress,
+				achievement.Goal, status)},
+		)
+		if achievement.Completed() && !achievement.Claimed {
+			m.Elements = append(m.Elements, Button{Text: "Claim reward", Submit: func() {
+				var msg string
+				if err := a.Provider.Claim(submitter, achievement.ID); err != nil {
+					msg = err.Error()
+				}
+				submitter.SendForm(a.category(submitter, category, page, msg))
+			}})
+		}
+	}
+	if start > 0 {
+		m.AddButtons(Button{Text: "Previous page", Submit: func() {
+			submitter.SendForm(a.category(submitter, category, page-1, ""))
+		}})
+	}
+	if end < len(achievements) {
+		m.AddButtons(Button{Text: "Next page", Submit: func() {
+			submitter.SendForm(a.category(submitter, category, page+1, ""))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		submitter.SendForm(a.categories(submitter))
+	}})
+	return m
+}
+
+// categoryOf returns the category under which the achievement passed is listed.
+func categoryOf(a Achievement) string {
+	if a.Category == "" {
+		return "General"
+	}
+	return a.Category
+}
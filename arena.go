@@ -0,0 +1,132 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// ArenaState is the state of an Arena.
+type ArenaState int
+
+const (
+	// ArenaOpen is the state of an arena that may be joined.
+	ArenaOpen ArenaState = iota
+	// ArenaFull is the state of an arena that has no places left.
+	ArenaFull
+	// ArenaInProgress is the state of an arena of which the game has already started.
+	ArenaInProgress
+)
+
+// String ...
+func (s ArenaState) String() string {
+	switch s {
+	case ArenaOpen:
+		return "Open"
+	case ArenaFull:
+		return "Full"
+	case ArenaInProgress:
+		return "In progress"
+	}
+	return "Unknown"
+}
+
+// color returns the formatting code of the color in which the state is displayed.
+func (s ArenaState) color() string {
+	switch s {
+	case ArenaOpen:
+		return "§a"
+	case ArenaFull:
+		return "§c"
+	}
+	return "§6"
+}
+
+// Arena is an arena or instance of a game that may be joined using an ArenaPicker.
+type Arena struct {
+	// ID is the ID of the arena, which may be used by the Reserve and Join functions to find the arena.
+	ID string
+	// Name is the name of the arena displayed on its button. It may contain Minecraft formatting codes.
+	Name string
+	// Players is the amount of players currently in the arena.
+	Players int
+	// Capacity is the maximum amount of players in the arena. If zero, the capacity is not displayed.
+	Capacity int
+	// State is the state of the arena. Only arenas that are ArenaOpen may be joined.
+	State ArenaState
+}
+
+// ArenaPicker represents a menu used to pick an arena to join, such as a minigame instance. Every arena is displayed
+// with its capacity and state, and the arenas are obtained again every time the picker is sent, so that the menu is
+// up to date whenever it is reopened.
+type ArenaPicker struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Arenas returns the arenas displayed in the picker, in the order that they are displayed.
+	Arenas func() []Arena
+	// Icon returns the image displayed on the button of an arena, such as an icon matching its state. Icon may be nil,
+	// in which case no images are displayed.
+	Icon func(a Arena) string
+	// Reserve is called when the player picks an open arena and reserves a place in it for the player. Because the
+	// arena may have filled up since the picker was sent, Reserve should check the capacity of the arena again
+	// atomically and return an error if the player cannot join it, in which case the error is displayed to the player
+	// in the picker. Reserve may be nil.
+	Reserve func(submitter form.Submitter, a Arena) error
+	// Join is called when the player picks an open arena and its place was reserved successfully.
+	Join func(submitter form.Submitter, a Arena)
+}
+
+// Send sends the picker to the submitter passed, with the arenas currently returned by Arenas.
+func (p ArenaPicker) Send(submitter form.Submitter) {
+	submitter.SendForm(p.menu(submitter, ""))
+}
+
+// menu returns the Menu of the picker, with the error passed displayed under the content.
+func (p ArenaPicker) menu(submitter form.Submitter, err string) *Menu {
+	content := p.Content
+	if err != "" {
+		content = strings.TrimSpace(content + "\n\n§c" + err)
+	}
+	m := NewMenu(p.Title, content)
+	var arenas []Arena
+	if p.Arenas != nil {
+		arenas = p.Arenas()
+	}
+	for _, a := range arenas {
+		a := a
+		text := a.Name + "\n" + a.State.color() + a.State.String()
+		if a.Capacity > 0 {
+			text += fmt.Sprintf(" §8(%v/%v)", a.Players, a.Capacity)
+		}
+		var image string
+		if p.Icon != nil {
+			image = p.Icon(a)
+		}
+		m.AddButtons(Button{Text: text, Image: image, Submit: func() {
+			p.pick(submitter, a)
+		}})
+	}
+	m.AddButtons(Button{Text: "Refresh", Submit: func() {
+		p.Send(submitter)
+	}})
+	return m
+}
+
+// pick is called when the submitter passed picks the arena passed.
+func (p ArenaPicker) pick(submitter form.Submitter, a Arena) {
+	if a.State != ArenaOpen {
+		submitter.SendForm(p.menu(submitter, fmt.Sprintf("%v is %v.", a.Name, strings.ToLower(a.State.String()))))
+		return
+	}
+	if p.Reserve != nil {
+		if err := p.Reserve(submitter, a); err != nil {
+			submitter.SendForm(p.menu(submitter, err.Error()))
+			return
+		}
+	}
+	if p.Join != nil {
+		p.Join(submitter, a)
+	}
+}
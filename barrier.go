@@ -0,0 +1,187 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// BarrierStatus is the status of a single submitter of a Barrier.
+type BarrierStatus int
+
+const (
+	// BarrierPending is the status of a submitter that has not yet responded to its form.
+	BarrierPending BarrierStatus = iota
+	// BarrierSubmitted is the status of a submitter that submitted its form successfully.
+	BarrierSubmitted
+	// BarrierClosed is the status of a submitter that closed its form, or whose response returned an error.
+	BarrierClosed
+)
+
+// String ...
+func (s BarrierStatus) String() string {
+	switch s {
+	case BarrierPending:
+		return "pending"
+	case BarrierSubmitted:
+		return "submitted"
+	case BarrierClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// Barrier sends a form to a group of submitters and only proceeds once enough of them have submitted it, such as for
+// party ready checks or the start of a match. A Barrier must not be copied after it was first sent.
+type Barrier struct {
+	// Quorum is the amount of submitters that must submit their form for the barrier to pass. If zero, all
+	// submitters must submit their form.
+	Quorum int
+	// Timeout is the time after which the barrier fails if the quorum was not yet reached. If zero, the barrier
+	// waits until every submitter has responded.
+	Timeout time.Duration
+	// Done is called once the barrier is resolved, either because the quorum was reached, in which case passed is
+	// true, or because the quorum can no longer be reached or the timeout passed. The statuses passed hold the status
+	// of every submitter, in the same order as the submitters. Responses submitted after the barrier was resolved are
+	// ignored.
+	Done func(passed bool, statuses []BarrierStatus)
+
+	mu         sync.Mutex
+	submitters []form.Submitter
+	statuses   []BarrierStatus
+	resolved   bool
+	timer      *time.Timer
+}
+
+// Send sends a form to every submitter passed. The form is built separately for every submitter using the function
+// passed. Send must only be called once per Barrier.
+func (b *Barrier) Send(submitters []form.Submitter, build func(s form.Submitter) form.Form) {
+	b.mu.Lock()
+	b.submitters = append([]form.Submitter(nil), submitters...)
+	b.statuses = make([]BarrierStatus, len(submitters))
+	if b.Timeout > 0 {
+		b.timer = time.AfterFunc(b.Timeout, func() {
+			b.mu.Lock()
+			b.resolve(false)
+		})
+	}
+	b.mu.Unlock()
+
+	if b.quorum() == 0 {
+		b.mu.Lock()
+		b.resolve(true)
+		return
+	}
+	for i, s := range submitters {
+		i := i
+		s.SendForm(barrierForm{Form: build(s), b: b, index: i})
+	}
+}
+
+// Status returns the status of the submitter passed. If the submitter is not part of the barrier, or if its type is
+// not comparable, BarrierPending is returned.
+func (b *Barrier) Status(submitter form.Submitter) BarrierStatus {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return BarrierPending
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.submitters {
+		if s == submitter {
+			return b.statuses[i]
+		}
+	}
+	return BarrierPending
+}
+
+// Statuses returns the status of every submitter of the barrier, in the same order as the submitters.
+func (b *Barrier) Statuses() []BarrierStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BarrierStatus(nil), b.statuses...)
+}
+
+// Resolved checks if the barrier was resolved.
+func (b *Barrier) Resolved() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.resolved
+}
+
+// quorum returns the amount of submitters that must submit their form for the barrier to pass.
+func (b *Barrier) quorum() int {
+	if b.Quorum <= 0 || b.Quorum > len(b.submitters) {
+		return len(b.submitters)
+	}
+	return b.Quorum
+}
+
+// update updates the status of the submitter at the index passed and resolves the barrier if the quorum was reached
+// or can no longer be reached.
+func (b *Barrier) update(index int, status BarrierStatus) {
+	b.mu.Lock()
+	if b.resolved {
+		b.mu.Unlock()
+		return
+	}
+	b.statuses[index] = status
+
+	var submitted, pending int
+	for _, s := range b.statuses {
+		switch s {
+		case BarrierSubmitted:
+			submitted++
+		case BarrierPending:
+			pending++
+		}
+	}
+	switch q := b.quorum(); {
+	case submitted >= q:
+		b.resolve(true)
+	case submitted+pending < q:
+		b.resolve(false)
+	default:
+		b.mu.Unlock()
+	}
+}
+
+// resolve resolves the barrier and calls Done. It must be called with the mutex of the barrier locked, and unlocks it.
+func (b *Barrier) resolve(passed bool) {
+	if b.resolved {
+		b.mu.Unlock()
+		return
+	}
+	b.resolved = true
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	statuses := append([]BarrierStatus(nil), b.statuses...)
+	b.mu.Unlock()
+
+	if b.Done != nil {
+		b.Done(passed, statuses)
+	}
+}
+
+// barrierForm is the form of a single submitter of a Barrier.
+type barrierForm struct {
+	form.Form
+	b     *Barrier
+	index int
+}
+
+// SubmitJSON ...
+func (f barrierForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if f.b.Resolved() {
+		// The barrier was already resolved, so the response is no longer relevant.
+		return nil
+	}
+	err := f.Form.SubmitJSON(data, submitter)
+	status := BarrierSubmitted
+	if data == nil || err != nil {
+		status = BarrierClosed
+	}
+	f.b.update(f.index, status)
+	return err
+}
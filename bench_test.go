@@ -0,0 +1,138 @@
+package form
+
+import (
+	"encoding/json"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchSubmitter is a form.Submitter that discards the forms sent to it.
+type benchSubmitter struct{}
+
+// SendForm ...
+func (benchSubmitter) SendForm(form.Form) {}
+
+// BenchmarkCustomMarshal measures encoding a Custom form with one element of every common type.
+func BenchmarkCustomMarshal(b *testing.B) {
+	c := NewCustom("Settings",
+		Label{Text: "Change your settings below."},
+		Input{Text: "Name", Placeholder: "Steve"},
+		Toggle{Text: "Notifications", Default: true},
+		Slider{Text: "Volume", Min: 0, Max: 100, StepSize: 5, Default: 50},
+		Dropdown{Text: "Language", Options: []string{"English", "Dutch", "German"}},
+		StepSlider{Text: "Difficulty", Options: []string{"Easy", "Normal", "Hard"}, DefaultIndex: 1},
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMenuSubmit measures submitting a click on the last button of a Menu with 50 buttons.
+func BenchmarkMenuSubmit(b *testing.B) {
+	m := NewMenu("Warps", "Select a warp.")
+	for i := 0; i < 50; i++ {
+		m.AddButtons(Button{Text: "Warp " + strconv.Itoa(i), Submit: func() {}})
+	}
+	data := []byte("49")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := m.SubmitJSON(data, benchSubmitter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// soakSubmitter is a form.Submitter that encodes every form sent to it and immediately submits the scripted response
+// for it, as a client would without a player.
+type soakSubmitter struct {
+	responses map[string][]byte
+	err       error
+}
+
+// SendForm ...
+func (s *soakSubmitter) SendForm(f form.Form) {
+	data, err := f.MarshalJSON()
+	if err != nil {
+		s.err = err
+		return
+	}
+	var typ struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &typ); err != nil {
+		s.err = err
+		return
+	}
+	if err := f.SubmitJSON(s.responses[typ.Type], s); err != nil {
+		s.err = err
+	}
+}
+
+// BenchmarkSoak sends a menu, a modal and a custom form with scripted responses from many submitters concurrently, and
+// reports the throughput in forms per second and the 50th, 95th and 99th percentile of the time taken to send and
+// submit a form.
+func BenchmarkSoak(b *testing.B) {
+	m := NewMenu("Warps", "Select a warp.")
+	for i := 0; i < 20; i++ {
+		m.AddButtons(Button{Text: "Warp " + strconv.Itoa(i), Submit: func() {}})
+	}
+	modal := &Modal{Title: "Confirm", Content: "Are you sure?", Button1: Button{Text: "Yes"}, Button2: Button{Text: "No"},
+		Submit: func(bool) {}}
+	c := NewCustom("Settings",
+		Input{Text: "Name", MaxLength: 16, Truncate: true},
+		Toggle{Text: "Notifications"},
+		Slider{Text: "Volume", Max: 100, StepSize: 5, Steps: StepsSnap},
+		Dropdown{Text: "Language", Options: []string{"English", "Dutch", "German"}},
+	)
+	c.Submit = func(bool, []any) {}
+	forms := []form.Form{m, modal, c}
+	responses := map[string][]byte{
+		"form":        []byte("19"),
+		"modal":       []byte("true"),
+		"custom_form": []byte(`["Steve", true, 42.5, 1]`),
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies = make([]time.Duration, 0, b.N)
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		s := &soakSubmitter{responses: responses}
+		local := make([]time.Duration, 0, 1024)
+		for i := 0; pb.Next(); i++ {
+			t := time.Now()
+			s.SendForm(forms[i%len(forms)])
+			local = append(local, time.Since(t))
+		}
+		if s.err != nil {
+			b.Error(s.err)
+		}
+		mu.Lock()
+		latencies = append(latencies, local...)
+		mu.Unlock()
+	})
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) float64 {
+		return float64(latencies[int(p*float64(len(latencies)-1))].Nanoseconds())
+	}
+	b.ReportMetric(float64(len(latencies))/elapsed.Seconds(), "forms/s")
+	b.ReportMetric(percentile(0.50), "p50-ns")
+	b.ReportMetric(percentile(0.95), "p95-ns")
+	b.ReportMetric(percentile(0.99), "p99-ns")
+}
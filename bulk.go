@@ -0,0 +1,103 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+)
+
+// Outcome is the outcome of a form sent to, or applied for, a single submitter.
+type Outcome struct {
+	// Submitter is the submitter that the form was sent to or applied for.
+	Submitter form.Submitter
+	// Closed is true if the submitter closed the form instead of submitting it.
+	Closed bool
+	// Err is the error returned when submitting the response of the submitter to the form, if any.
+	Err error
+}
+
+// SendAll sends a form to every submitter passed. The form is built separately for every submitter using the function
+// passed, so that it may be filled out with the current settings of the submitter. Once every submitter has either
+// submitted or closed its form, done is called with the outcome for every submitter, in the same order as the
+// submitters. Note that done is never called if one of the submitters never responds, for example because it
+// disconnected.
+func SendAll(submitters []form.Submitter, build func(s form.Submitter) form.Form, done func(outcomes []Outcome)) {
+	if len(submitters) == 0 {
+		if done != nil {
+			done(nil)
+		}
+		return
+	}
+	outcomes := make([]Outcome, len(submitters))
+	var mu sync.Mutex
+	left := len(submitters)
+	for i, s := range submitters {
+		i, s := i, s
+		s.SendForm(outcomeForm{Form: build(s), outcome: func(o Outcome) {
+			mu.Lock()
+			outcomes[i] = o
+			left--
+			finished := left == 0
+			mu.Unlock()
+			if finished && done != nil {
+				done(outcomes)
+			}
+		}})
+	}
+}
+
+// ApplyAll applies a Custom form for every submitter passed without sending it, as if every submitter submitted the
+// form without changing any of its defaults. The form is built separately for every submitter using the function
+// passed, so that its defaults may be used to programmatically change settings through the same handlers as those
+// used when the form is submitted by a player. ApplyAll returns the outcome for every submitter, in the same order as
+// the submitters.
+func ApplyAll(submitters []form.Submitter, build func(s form.Submitter) *Custom) []Outcome {
+	outcomes := make([]Outcome, len(submitters))
+	for i, s := range submitters {
+		outcomes[i] = Outcome{Submitter: s, Err: SubmitDefaults(build(s), s)}
+	}
+	return outcomes
+}
+
+// SubmitDefaults submits a Custom form as if the submitter passed submitted it without changing any of its defaults.
+func SubmitDefaults(c *Custom, submitter form.Submitter) error {
+	values := make([]any, 0, len(c.Elements))
+	for _, element := range c.content() {
+		data, err := element.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		var e struct {
+			Type    string          `json:"type"`
+			Default json.RawMessage `json:"default"`
+		}
+		if err := json.Unmarshal(data, &e); err != nil {
+			return fmt.Errorf("error decoding element JSON: %w", err)
+		}
+		switch e.Type {
+		case "label", "header", "divider":
+			values = append(values, nil)
+		default:
+			values = append(values, e.Default)
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return c.SubmitJSON(data, submitter)
+}
+
+// outcomeForm is a form that reports its Outcome once it is submitted or closed.
+type outcomeForm struct {
+	form.Form
+	outcome func(o Outcome)
+}
+
+// SubmitJSON ...
+func (f outcomeForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	err := f.Form.SubmitJSON(data, submitter)
+	f.outcome(Outcome{Submitter: submitter, Closed: data == nil, Err: err})
+	return err
+}
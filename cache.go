@@ -0,0 +1,163 @@
+package form
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+	"time"
+)
+
+// ResponseCache remembers the responses of players to prompts that they chose not to be asked again for a while, such
+// as settings that are asked for before every match. When a prompt is sent through the cache, a toggle to not be asked
+// again is added to it. If the player enables it, its response is reused for the TTL of the cache instead of sending
+// the prompt again. Responses are cached by the ID of the prompt and a hash of its content, so that a changed prompt
+// is always asked again. The zero value of a ResponseCache caches responses for 10 minutes. A ResponseCache is safe for
+// concurrent use.
+type ResponseCache struct {
+	// TTL is the time for which a response is reused. If zero, responses are reused for 10 minutes.
+	TTL time.Duration
+	// Text is the text of the toggle added to prompts. If empty, "Don't ask again for {ttl}" is used.
+	Text string
+
+	mu        sync.Mutex
+	responses map[[3]string]cachedResponse
+}
+
+// cachedResponse is a response cached in a ResponseCache.
+type cachedResponse struct {
+	data    []byte
+	expires time.Time
+}
+
+// Send sends the prompt passed with the ID passed to the submitter passed, which is the player with the key passed.
+// If the player chose to not be asked again and its response has not yet expired, the prompt is not sent, but
+// submitted with the cached response right away, and Send returns true. The prompt must be a *Custom form, or a form
+// returned by Dismissible.Wrap for a *Custom form, so that it may be both cached and dismissed.
+func (c *ResponseCache) Send(submitter form.Submitter, key, id string, prompt form.Form) (cached bool, err error) {
+	data, err := prompt.MarshalJSON()
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(data)
+	k := [3]string{key, id, string(sum[:])}
+
+	c.mu.Lock()
+	r, ok := c.responses[k]
+	if ok && time.Now().After(r.expires) {
+		delete(c.responses, k)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return true, prompt.SubmitJSON(r.data, submitter)
+	}
+	submitter.SendForm(trailingToggle{Form: prompt, text: c.text(), submit: func(remember bool, data []byte) {
+		if remember {
+			c.store(k, data)
+		}
+	}})
+	return false, nil
+}
+
+// Forget removes all responses cached for the player with the key passed, so that it is asked every prompt again.
+func (c *ResponseCache) Forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.responses {
+		if k[0] == key {
+			delete(c.responses, k)
+		}
+	}
+}
+
+// ttl returns the time for which a response is reused.
+func (c *ResponseCache) ttl() time.Duration {
+	if c.TTL <= 0 {
+		return time.Minute * 10
+	}
+	return c.TTL
+}
+
+// text returns the text of the toggle added to prompts.
+func (c *ResponseCache) text() string {
+	if c.Text != "" {
+		return c.Text
+	}
+	return fmt.Sprintf("Don't ask again for %v", c.ttl().Round(time.Second))
+}
+
+// store caches the response data passed under the key passed.
+func (c *ResponseCache) store(k [3]string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.responses == nil {
+		c.responses = make(map[[3]string]cachedResponse)
+	}
+	c.responses[k] = cachedResponse{data: data, expires: time.Now().Add(c.ttl())}
+}
+
+// trailingToggle is a form encoded as a Custom form with a toggle added to the bottom of it, such as to not be asked
+// again. The form wrapped may itself be a trailingToggle, so that multiple toggles may be added to the same form.
+type trailingToggle struct {
+	form.Form
+	text string
+	// submit is called with the value of the toggle and the response without it once the response was submitted to
+	// the form wrapped without error.
+	submit func(enabled bool, data []byte)
+}
+
+// MarshalJSON ...
+func (f trailingToggle) MarshalJSON() ([]byte, error) {
+	data, err := f.Form.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("error decoding form JSON: %w", err)
+	}
+	var typ string
+	var content []json.RawMessage
+	if err := json.Unmarshal(m["type"], &typ); err != nil || typ != "custom_form" {
+		return nil, fmt.Errorf("toggle can only be added to custom forms")
+	} else if err := json.Unmarshal(m["content"], &content); err != nil {
+		return nil, fmt.Errorf("error decoding form content: %w", err)
+	}
+	toggle, err := Toggle{Text: f.text}.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if m["content"], err = json.Marshal(append(content, toggle)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// SubmitJSON ...
+func (f trailingToggle) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if data == nil {
+		return f.Form.SubmitJSON(nil, submitter)
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("error decoding JSON data to slice: %w", err)
+	} else if len(values) == 0 {
+		return fmt.Errorf("form JSON data array does not have enough values")
+	}
+	// The toggle is the last element of the form, so its value is always the last value.
+	var enabled bool
+	if err := json.Unmarshal(values[len(values)-1], &enabled); err != nil {
+		return fmt.Errorf("value %s is not allowed for toggle element", values[len(values)-1])
+	}
+	data, err := json.Marshal(values[:len(values)-1])
+	if err != nil {
+		return err
+	}
+	if err := f.Form.SubmitJSON(data, submitter); err != nil {
+		return err
+	}
+	f.submit(enabled, data)
+	return nil
+}
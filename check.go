@@ -0,0 +1,72 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+)
+
+// Check checks if every interactive element of the form passed is handled, either by the element's own Submit
+// function or by the Submit function of the form. Buttons or elements that are handled by neither do nothing when
+// used by a player, which is most likely a mistake. Check returns an error describing the first unhandled element
+// found. Forms wrapped using DisplayOnly, and forms of types other than *Menu, *Modal and *Custom, are not checked.
+func Check(f form.Form) error {
+	switch f := f.(type) {
+	case *Menu:
+		if f.Submit != nil {
+			return nil
+		}
+		for i, element := range f.clickable() {
+			if !handled(element) {
+				return fmt.Errorf("menu button %v (%T) has no Submit and the menu has no Submit", i, element)
+			}
+		}
+	case *Modal:
+		if f.Submit != nil {
+			return nil
+		}
+		if !handled(f.Button1) {
+			return fmt.Errorf("modal button 1 has no Submit and the modal has no Submit")
+		} else if !handled(f.Button2) {
+			return fmt.Errorf("modal button 2 has no Submit and the modal has no Submit")
+		}
+	case *Custom:
+		if f.Submit != nil {
+			return nil
+		}
+		for i, element := range f.Elements {
+			if !handled(element) {
+				return fmt.Errorf("custom form element %v (%T) has no Submit and the form has no Submit", i, element)
+			}
+		}
+	}
+	return nil
+}
+
+// DisplayOnly marks a form as only displaying information, so that Check does not return an error for any of its
+// elements being unhandled.
+func DisplayOnly(f form.Form) form.Form {
+	return displayOnly{Form: f}
+}
+
+// displayOnly is a form marked using DisplayOnly.
+type displayOnly struct {
+	form.Form
+}
+
+// handled checks if the element passed is either not interactive or has a Submit function set. An element is
+// considered interactive if it is a struct with a field named Submit holding a function.
+func handled(element any) bool {
+	v := reflect.ValueOf(unwrap(element))
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return true
+	}
+	submit := v.FieldByName("Submit")
+	return !submit.IsValid() || submit.Kind() != reflect.Func || !submit.IsNil()
+}
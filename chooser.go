@@ -0,0 +1,108 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"sort"
+	"strings"
+)
+
+// Chooser represents a set of menus used to choose an option out of a very large set of options, such as every item
+// or block in the game. Instead of a single dropdown holding all options, which is hard to use on console clients,
+// the options are sorted and narrowed down through successive small menus, such as 'A - F', 'G - M' and so on, until
+// few enough options are left to list them.
+type Chooser struct {
+	// Title is the title of the menus.
+	Title string
+	// Options holds the options that may be chosen. They do not need to be sorted.
+	Options []string
+	// Branches is the amount of ranges that the options are split into in every menu. If zero, options are split into
+	// 6 ranges.
+	Branches int
+	// MaxOptions is the maximum amount of options listed in a single menu. If more options are left, they are split
+	// into ranges. If zero, at most 10 options are listed.
+	MaxOptions int
+	// Submit is called with the index in Options and the option chosen by the player.
+	Submit func(index int, option string)
+}
+
+// Send sends the first menu of the chooser to the submitter passed.
+func (c Chooser) Send(submitter form.Submitter) {
+	indices := make([]int, len(c.Options))
+	for i := range indices {
+		indices[i] = i
+	}
+	keys := make([]string, len(c.Options))
+	for i, option := range c.Options {
+		keys[i] = strings.ToLower(text.Clean(option))
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return keys[indices[i]] < keys[indices[j]]
+	})
+	submitter.SendForm(c.menu(submitter, indices, keys, nil))
+}
+
+// menu returns the menu narrowing down the options with the indices passed, which are sorted by their keys. parent
+// is the menu that the menu returned goes back to, which is nil for the first menu.
+func (c Chooser) menu(submitter form.Submitter, indices []int, keys []string, parent *Menu) *Menu {
+	maxOptions, branches := c.MaxOptions, c.Branches
+	if maxOptions <= 0 {
+		maxOptions = 10
+	}
+	if branches < 2 {
+		branches = 6
+	}
+	m := NewMenu(c.Title, fmt.Sprintf("%v options.", len(indices)))
+	if len(indices) <= maxOptions {
+		for _, i := range indices {
+			i := i
+			m.AddButtons(Button{Text: c.Options[i], Submit: func() {
+				if c.Submit != nil {
+					c.Submit(i, c.Options[i])
+				}
+			}})
+		}
+	} else {
+		size := (len(indices) + branches - 1) / branches
+		for start := 0; start < len(indices); start += size {
+			end := start + size
+			if end > len(indices) {
+				end = len(indices)
+			}
+			group := indices[start:end]
+			var before, after string
+			if start > 0 {
+				before = keys[indices[start-1]]
+			}
+			if end < len(indices) {
+				after = keys[indices[end]]
+			}
+			first, last := keys[group[0]], keys[group[len(group)-1]]
+			label := strings.ToUpper(distinguish(first, before))
+			if l := strings.ToUpper(distinguish(last, after)); l != label {
+				label += " - " + l
+			}
+			m.AddButtons(Button{Text: fmt.Sprintf("%v\n§7%v options", label, len(group)), Submit: func() {
+				submitter.SendForm(c.menu(submitter, group, keys, m))
+			}})
+		}
+	}
+	if parent != nil {
+		m.AddButtons(Button{Text: "Back", Submit: func() {
+			submitter.SendForm(parent)
+		}})
+	}
+	return m
+}
+
+// distinguish returns the shortest prefix of s that is not a prefix of other, or s itself if no such prefix exists.
+func distinguish(s, other string) string {
+	r, o := []rune(s), []rune(other)
+	for i := range r {
+		if i >= len(o) || r[i] != o[i] {
+			return string(r[:i+1])
+		}
+	}
+	return s
+}
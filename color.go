@@ -0,0 +1,94 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+)
+
+// NamedColor is a color with a name, which may be selected in a ColorPicker.
+type NamedColor struct {
+	// Name is the name of the color displayed as option of the dropdown. The name may contain Minecraft formatting
+	// codes.
+	Name string
+	// Color is the color submitted if the option is selected.
+	Color color.RGBA
+}
+
+// ColorPicker represents a group of elements used to select a color. It is rendered as three sliders for the red,
+// green and blue components of the color, or as a dropdown if Colors is not empty.
+type ColorPicker struct {
+	// Text is the text displayed over the color picker. If the picker is rendered as sliders, the text is displayed in
+	// a label over the sliders, and no label is added if it is empty. The text may contain Minecraft formatting codes.
+	Text string
+	// Colors holds the named colors that may be selected. If not empty, the picker is rendered as a dropdown of these
+	// colors rather than three sliders.
+	Colors []NamedColor
+	// Default is the color selected by default. If the picker is rendered as a dropdown and none of the Colors equal
+	// Default, the first color is selected by default.
+	Default color.RGBA
+	// Submit is called with the color selected by the player whenever they submit the form. The alpha component of the
+	// color is always 255. If the form is closed, this method is not called. This is always called before the Form's
+	// Submit.
+	Submit func(c color.RGBA)
+}
+
+// MarshalJSON ...
+func (c ColorPicker) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.elements())
+}
+
+// elements ...
+func (c ColorPicker) elements() []Element {
+	if len(c.Colors) != 0 {
+		options := make([]string, len(c.Colors))
+		var def int
+		for i, named := range c.Colors {
+			options[i] = named.Name
+			if named.Color == c.Default {
+				def = i
+			}
+		}
+		return []Element{Dropdown{Text: c.Text, Options: options, DefaultIndex: def}}
+	}
+	var elements []Element
+	if c.Text != "" {
+		elements = append(elements, Label{Text: c.Text})
+	}
+	return append(elements,
+		IntSlider{Text: "§cRed", Max: 255, Default: int(c.Default.R)},
+		IntSlider{Text: "§aGreen", Max: 255, Default: int(c.Default.G)},
+		IntSlider{Text: "§9Blue", Max: 255, Default: int(c.Default.B)},
+	)
+}
+
+// SubmitValue ...
+func (c ColorPicker) SubmitValue(value any) error {
+	if c.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) == 0 || (len(c.Colors) == 0 && len(values) < 3) {
+		return fmt.Errorf("value %v is not allowed for color picker element", value)
+	}
+	if len(c.Colors) != 0 {
+		var index int
+		dropdown := Dropdown{Options: make([]string, len(c.Colors)), Submit: func(i int, _ string) { index = i }}
+		if err := dropdown.SubmitValue(values[0]); err != nil {
+			return err
+		}
+		col := c.Colors[index].Color
+		col.A = 255
+		c.Submit(col)
+		return nil
+	}
+	var components [3]uint8
+	for i, v := range values[len(values)-3:] {
+		i := i
+		if err := (IntSlider{Max: 255, Submit: func(n int) { components[i] = uint8(n) }}).SubmitValue(v); err != nil {
+			return err
+		}
+	}
+	c.Submit(color.RGBA{R: components[0], G: components[1], B: components[2], A: 255})
+	return nil
+}
@@ -0,0 +1,82 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Cooldown limits how often a form may be opened by the same player, protecting forms that are expensive to build,
+// such as shops backed by a database, from players repeatedly opening them. A Cooldown should be used for a single
+// form, or for a group of forms that share a cooldown. The zero value of a Cooldown has no cooldown. A Cooldown is
+// safe for concurrent use.
+type Cooldown struct {
+	// Duration is the minimum time between two sends of the form to the same player.
+	Duration time.Duration
+	// Wait is called to build the form sent instead of the form while the player is on cooldown, with the time left
+	// until the form may be opened again. If nil, a modal asking the player to wait is sent.
+	Wait func(left time.Duration) form.Form
+
+	mu   sync.Mutex
+	last map[form.Submitter]time.Time
+}
+
+// Send sends the form passed to the submitter if it is not on cooldown, and puts the submitter on cooldown. If the
+// submitter is on cooldown, the form returned by Wait is sent instead and false is returned. The function passed is
+// only called to build the form if the submitter is not on cooldown. Submitters that cannot be compared, and thus
+// cannot be tracked, are never put on cooldown.
+func (c *Cooldown) Send(submitter form.Submitter, f func() form.Form) bool {
+	if c.Duration <= 0 || !reflect.TypeOf(submitter).Comparable() {
+		submitter.SendForm(f())
+		return true
+	}
+	now := time.Now()
+	c.mu.Lock()
+	if c.last == nil {
+		c.last = make(map[form.Submitter]time.Time)
+	}
+	if last, ok := c.last[submitter]; ok && now.Sub(last) < c.Duration {
+		c.mu.Unlock()
+		submitter.SendForm(c.wait(c.Duration - now.Sub(last)))
+		return false
+	}
+	c.last[submitter] = now
+	for s, last := range c.last {
+		// Clean up submitters of which the cooldown expired so that the map does not keep growing.
+		if now.Sub(last) >= c.Duration {
+			delete(c.last, s)
+		}
+	}
+	c.mu.Unlock()
+
+	submitter.SendForm(f())
+	return true
+}
+
+// Reset removes the cooldown of the submitter passed, so that the form may be opened again immediately.
+func (c *Cooldown) Reset(submitter form.Submitter) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.last, submitter)
+}
+
+// wait returns the form sent to a submitter that is on cooldown for the duration passed.
+func (c *Cooldown) wait(left time.Duration) form.Form {
+	if c.Wait != nil {
+		return c.Wait(left)
+	}
+	if left < time.Second {
+		left = time.Second
+	}
+	return &Modal{
+		Title:   "Please wait",
+		Content: fmt.Sprintf("You must wait %v before opening this form again.", left.Round(time.Second)),
+		Button1: Button{Text: "OK"},
+		Button2: Button{Text: "Close"},
+	}
+}
@@ -0,0 +1,71 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// CoordinateInput represents a group of three text inputs in which the X, Y and Z coordinates of a position must be
+// filled out. Submitters cannot submit coordinates that are not numbers.
+type CoordinateInput struct {
+	// Text is the text displayed in a label over the inputs. If empty, no label is added. The text may contain
+	// Minecraft formatting codes.
+	Text string
+	// Default is the position filled out in the inputs by default, such as the current position of the submitter.
+	Default mgl64.Vec3
+	// Submit is called with the position provided by the player whenever they submit the form. If the form is closed,
+	// this method is not called. This is always called before the Form's Submit.
+	Submit func(pos mgl64.Vec3)
+	// SubmitBlock is called with the block position of the position provided by the player whenever they submit the
+	// form, rounded down. If the form is closed, this method is not called. This is always called after Submit and
+	// before the Form's Submit.
+	SubmitBlock func(pos cube.Pos)
+}
+
+// MarshalJSON ...
+func (c CoordinateInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.elements())
+}
+
+// elements ...
+func (c CoordinateInput) elements() []Element {
+	var elements []Element
+	if c.Text != "" {
+		elements = append(elements, Label{Text: c.Text})
+	}
+	for i, axis := range [3]string{"X", "Y", "Z"} {
+		elements = append(elements, Input{Text: axis, Default: formatFloat(c.Default[i]), Placeholder: axis})
+	}
+	return elements
+}
+
+// SubmitValue ...
+func (c CoordinateInput) SubmitValue(value any) error {
+	if c.Submit == nil && c.SubmitBlock == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) < 3 {
+		return fmt.Errorf("value %v is not allowed for coordinate input element", value)
+	}
+	var pos mgl64.Vec3
+	for i, v := range values[len(values)-3:] {
+		n, err := parseNumber(v)
+		if err != nil {
+			return fmt.Errorf("coordinate %v: %w", i, err)
+		} else if math.IsNaN(n) || math.IsInf(n, 0) {
+			return fmt.Errorf("coordinate %v: value %v is not a number", i, n)
+		}
+		pos[i] = n
+	}
+	if c.Submit != nil {
+		c.Submit(pos)
+	}
+	if c.SubmitBlock != nil {
+		c.SubmitBlock(cube.PosFromVec3(pos))
+	}
+	return nil
+}
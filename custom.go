@@ -16,20 +16,89 @@ type Custom struct {
 	// Buttons is a slice of elements that can be modified by a player. There must be at least one element for the client
 	// to render the form.
 	Elements []Element
+	// OmitEmpty specifies if optional fields of elements, such as defaults and placeholders, should be left out of the
+	// JSON sent to the client if they hold their zero value. This reduces the size of forms with many elements.
+	OmitEmpty bool
 	// Submit is called when the form is closed or if a player pressed the submit button. This is always called after the
-	// Submit of every Element. The values will be passed in a slice, with the same order as the Elements slice. If the
-	// form was closed, the values slice will be nil.
+	// Submit of every Element. The values will be passed in a slice, with the same order as the Elements slice. Values of
+	// elements made up of multiple underlying elements, such as a RangeInput, are passed as a []any. The text of an
+	// Input and the value of a Slider are passed as accepted by the element: truncated to its maximum length and
+	// aligned to its steps respectively, with the value of a Slider as a float64. If the form was closed, the values
+	// slice will be nil.
 	Submit func(closed bool, values []any)
+	// SubmitMeta is called when the form is closed or if a player pressed the submit button, just before Submit. Unlike
+	// Submit, it is passed the metadata of every element together with its value, so that generic handlers, such as
+	// audit logs, may handle the values without knowledge of the form. If the form was closed, values is nil.
+	SubmitMeta func(closed bool, values []ElementValue)
+}
+
+// ElementMeta holds metadata of an element of a Custom form, as sent to the client.
+type ElementMeta struct {
+	// Type is the type of the element, such as 'input' or 'slider'. It is empty for elements made up of multiple
+	// underlying elements, such as a RangeInput.
+	Type string `json:"type"`
+	// Text is the text displayed over the element.
+	Text string `json:"text"`
+	// Min, Max and StepSize are the range and step size of sliders.
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	StepSize float64 `json:"step"`
+	// Options holds the options of dropdowns and step sliders.
+	Options []string `json:"options"`
+}
+
+// Meta returns the metadata of the element passed. It is obtained from the JSON of the element, so that it is also
+// available for elements implemented outside this package.
+func Meta(element Element) ElementMeta {
+	var m struct {
+		ElementMeta
+		Steps []string `json:"steps"`
+	}
+	data, err := element.MarshalJSON()
+	if err != nil || json.Unmarshal(data, &m) != nil {
+		return ElementMeta{}
+	}
+	if m.Steps != nil {
+		m.Options = m.Steps
+	}
+	return m.ElementMeta
+}
+
+// ElementValue holds the value submitted for an element of a Custom form, together with the element's metadata.
+type ElementValue struct {
+	ElementMeta
+	// Index is the index of the element in the Elements of the form.
+	Index int
+	// Element is the element for which the value was submitted.
+	Element Element
+	// Value is the value submitted, as passed to the element's SubmitValue.
+	Value any
+}
+
+// NewCustom creates a new Custom form with the title and elements passed. The Submit function of the form may be set
+// on the Custom form returned.
+func NewCustom(title string, elements ...Element) *Custom {
+	return &Custom{Title: title, Elements: elements}
 }
 
 // Element appends an element to the bottom of the form.
+//
+// Deprecated: Use AddElements, which appends any number of elements at once.
 func (form *Custom) Element(element Element) {
 	form.Elements = append(form.Elements, element)
 }
 
+// AddElements appends the elements passed to the bottom of the form, in order.
+func (form *Custom) AddElements(elements ...Element) {
+	form.Elements = append(form.Elements, elements...)
+}
+
 // SubmitJSON ...
 func (form *Custom) SubmitJSON(data []byte, _ form.Submitter) error {
 	if data == nil {
+		if form.SubmitMeta != nil {
+			form.SubmitMeta(true, nil)
+		}
 		if form.Submit != nil {
 			form.Submit(true, nil)
 		}
@@ -40,17 +109,47 @@ func (form *Custom) SubmitJSON(data []byte, _ form.Submitter) error {
 	var inputData []any
 	if err := dec.Decode(&inputData); err != nil {
 		return fmt.Errorf("error decoding JSON data to slice: %w", err)
-	} else if len(form.Elements) != len(inputData) {
-		return fmt.Errorf("form JSON data array does not have enough values")
 	}
-	for i, element := range form.Elements {
-		err := element.submit(inputData[i])
-		if err != nil {
+	content := form.content()
+	if len(content) != len(inputData) {
+		// Some clients leave out the values of elements that do not accept input, such as labels, instead of sending
+		// null for them. If so, the values are mapped to the elements accepting input only.
+		if inputData = withDisplayValues(content, inputData); inputData == nil {
+			return fmt.Errorf("form JSON data array does not have enough values")
+		}
+	}
+	values := make([]any, 0, len(form.Elements))
+	for _, element := range form.Elements {
+		var value any
+		if c, ok := unwrap(element).(composite); ok {
+			n := len(c.elements())
+			value, inputData = inputData[:n:n], inputData[n:]
+		} else {
+			value, inputData = inputData[0], inputData[1:]
+		}
+		if display(element) {
+			// Clients send either null or an empty string for elements that do not accept input. Either way, the
+			// value is meaningless, so null is passed for it.
+			value = nil
+		}
+		if err := element.SubmitValue(value); err != nil {
 			return fmt.Errorf("error parsing form response value: %w", err)
 		}
+		if n, ok := unwrap(element).(normalizer); ok && value != nil {
+			// The value was already validated by SubmitValue, so normalizing it does not fail.
+			value, _ = n.normalize(value)
+		}
+		values = append(values, value)
+	}
+	if form.SubmitMeta != nil {
+		meta := make([]ElementValue, len(values))
+		for i, value := range values {
+			meta[i] = ElementValue{ElementMeta: Meta(form.Elements[i]), Index: i, Element: form.Elements[i], Value: value}
+		}
+		form.SubmitMeta(false, meta)
 	}
 	if form.Submit != nil {
-		form.Submit(false, inputData)
+		form.Submit(false, values)
 	}
 	return nil
 }
@@ -60,9 +159,89 @@ func (form *Custom) MarshalJSON() ([]byte, error) {
 	if len(form.Elements) == 0 {
 		return nil, errors.New("menu form requires at least one element")
 	}
+	elements := form.content()
+	var content any = elements
+	if form.OmitEmpty {
+		raw := make([]json.RawMessage, len(elements))
+		for i, element := range elements {
+			data, err := element.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			if raw[i], err = omitEmpty(data); err != nil {
+				return nil, err
+			}
+		}
+		content = raw
+	}
 	return json.Marshal(map[string]any{
 		"type":    "custom_form",
 		"title":   form.Title,
-		"content": form.Elements,
+		"content": content,
 	})
 }
+
+// optionalKeys holds the keys of optional fields of elements which are left out by omitEmpty if they hold their zero
+// value.
+var optionalKeys = []string{"default", "placeholder", "step"}
+
+// omitEmpty removes the optional fields holding their zero value from the JSON object of an element passed.
+func omitEmpty(data []byte) ([]byte, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("error decoding element JSON: %w", err)
+	}
+	for _, key := range optionalKeys {
+		switch string(m[key]) {
+		case `""`, "0", "false", "null":
+			delete(m, key)
+		}
+	}
+	return json.Marshal(m)
+}
+
+// withDisplayValues inserts a null value for every element in the content passed that does not accept input, if the
+// values passed hold exactly one value for every other element. If not, withDisplayValues returns nil.
+func withDisplayValues(content []Element, values []any) []any {
+	var n int
+	for _, element := range content {
+		if !display(element) {
+			n++
+		}
+	}
+	if n != len(values) {
+		return nil
+	}
+	all := make([]any, 0, len(content))
+	for _, element := range content {
+		if display(element) {
+			all = append(all, nil)
+			continue
+		}
+		all, values = append(all, values[0]), values[1:]
+	}
+	return all
+}
+
+// display checks if the element passed only displays information and does not accept input, such as a Label.
+func display(element Element) bool {
+	switch unwrap(element).(type) {
+	case Label, Header, Divider, TemplateLabel:
+		return true
+	}
+	return false
+}
+
+// content returns the elements of the form as they are sent to the client, expanding every composite element into
+// the elements it is made up of.
+func (form *Custom) content() []Element {
+	content := make([]Element, 0, len(form.Elements))
+	for _, element := range form.Elements {
+		if c, ok := unwrap(element).(composite); ok {
+			content = append(content, c.elements()...)
+			continue
+		}
+		content = append(content, element)
+	}
+	return content
+}
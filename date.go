@@ -0,0 +1,216 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DateInput represents a group of elements used to select a date, such as the date of an event. It is rendered as
+// text inputs for the year, month and day, or as sliders if Sliders is true. Submitters cannot submit a date that
+// does not exist, such as February 30th, or a date outside the range of the input.
+type DateInput struct {
+	// Text is the text displayed in a label over the elements. If empty, no label is added. The text may contain
+	// Minecraft formatting codes.
+	Text string
+	// Default is the date selected by default. If zero, the current date is selected.
+	Default time.Time
+	// Min and Max are the earliest and latest dates that may be submitted. If zero, there is no earliest or latest
+	// date. If the input is rendered as sliders, the year slider ranges from the year of Min to the year of Max,
+	// defaulting to the year of Default and ten years after it.
+	Min, Max time.Time
+	// Sliders specifies if the input should be rendered as sliders instead of text inputs.
+	Sliders bool
+	// Location is the location of the date submitted. If nil, time.Local is used.
+	Location *time.Location
+	// Submit is called with the date provided by the player, at midnight, whenever they submit the form. If the form
+	// is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(t time.Time)
+}
+
+// MarshalJSON ...
+func (d DateInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.elements())
+}
+
+// elements ...
+func (d DateInput) elements() []Element {
+	def := defaultTime(d.Default, d.Location)
+	var elements []Element
+	if d.Text != "" {
+		elements = append(elements, Label{Text: d.Text})
+	}
+	if !d.Sliders {
+		return append(elements,
+			Input{Text: "Year", Default: fmt.Sprint(def.Year()), Placeholder: "YYYY"},
+			Input{Text: "Month", Default: fmt.Sprint(int(def.Month())), Placeholder: "1-12"},
+			Input{Text: "Day", Default: fmt.Sprint(def.Day()), Placeholder: "1-31"},
+		)
+	}
+	minYear, maxYear := d.years(def)
+	months := make([]string, 12)
+	for i := range months {
+		months[i] = time.Month(i + 1).String()
+	}
+	return append(elements,
+		IntSlider{Text: "Year", Min: minYear, Max: maxYear, Default: def.Year()},
+		StepSlider{Text: "Month", Options: months, DefaultIndex: int(def.Month()) - 1},
+		IntSlider{Text: "Day", Min: 1, Max: 31, Default: def.Day()},
+	)
+}
+
+// years returns the range of years of the year slider of the input.
+func (d DateInput) years(def time.Time) (min, max int) {
+	min, max = def.Year(), def.Year()+10
+	if !d.Min.IsZero() {
+		min = d.Min.Year()
+	}
+	if !d.Max.IsZero() {
+		max = d.Max.Year()
+	}
+	return min, max
+}
+
+// SubmitValue ...
+func (d DateInput) SubmitValue(value any) error {
+	if d.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) < 3 {
+		return fmt.Errorf("value %v is not allowed for date input element", value)
+	}
+	parts, err := parseWholeNumbers(values[len(values)-3:])
+	if err != nil {
+		return err
+	}
+	year, month, day := parts[0], parts[1], parts[2]
+	if d.Sliders {
+		// The month is selected using a step slider, which submits the index of the month.
+		month++
+	}
+	loc := d.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if month < 1 || month > 12 || t.Day() != day {
+		return fmt.Errorf("date %04d-%02d-%02d does not exist", year, month, day)
+	}
+	if (!d.Min.IsZero() && t.Before(truncateDay(d.Min, loc))) || (!d.Max.IsZero() && t.After(d.Max)) {
+		return fmt.Errorf("date %v is out of range", t.Format("2006-01-02"))
+	}
+	d.Submit(t)
+	return nil
+}
+
+// TimeInput represents a group of elements used to select a time of day, such as the start time of an event. It is
+// rendered as text inputs for the hour and minute, or as sliders if Sliders is true.
+type TimeInput struct {
+	// Text is the text displayed in a label over the elements. If empty, no label is added. The text may contain
+	// Minecraft formatting codes.
+	Text string
+	// Default is the time selected by default. Its date is used as the date of the time submitted. If zero, the
+	// current time is selected.
+	Default time.Time
+	// Sliders specifies if the input should be rendered as sliders instead of text inputs.
+	Sliders bool
+	// Location is the location of the time submitted. If nil, time.Local is used.
+	Location *time.Location
+	// Submit is called with the time provided by the player whenever they submit the form. The date of the time is
+	// the date of Default. If the form is closed, this method is not called. This is always called before the Form's
+	// Submit.
+	Submit func(t time.Time)
+}
+
+// MarshalJSON ...
+func (t TimeInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.elements())
+}
+
+// elements ...
+func (t TimeInput) elements() []Element {
+	def := defaultTime(t.Default, t.Location)
+	var elements []Element
+	if t.Text != "" {
+		elements = append(elements, Label{Text: t.Text})
+	}
+	if !t.Sliders {
+		return append(elements,
+			Input{Text: "Hour", Default: fmt.Sprint(def.Hour()), Placeholder: "0-23"},
+			Input{Text: "Minute", Default: fmt.Sprint(def.Minute()), Placeholder: "0-59"},
+		)
+	}
+	return append(elements,
+		IntSlider{Text: "Hour", Max: 23, Default: def.Hour()},
+		IntSlider{Text: "Minute", Max: 59, Default: def.Minute()},
+	)
+}
+
+// SubmitValue ...
+func (t TimeInput) SubmitValue(value any) error {
+	if t.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) < 2 {
+		return fmt.Errorf("value %v is not allowed for time input element", value)
+	}
+	parts, err := parseWholeNumbers(values[len(values)-2:])
+	if err != nil {
+		return err
+	}
+	hour, minute := parts[0], parts[1]
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return fmt.Errorf("time %02d:%02d does not exist", hour, minute)
+	}
+	def := defaultTime(t.Default, t.Location)
+	t.Submit(time.Date(def.Year(), def.Month(), def.Day(), hour, minute, 0, 0, def.Location()))
+	return nil
+}
+
+// defaultTime returns the time passed in the location passed, or the current time if it is zero. If the location is
+// nil, time.Local is used.
+func defaultTime(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	if t.IsZero() {
+		return time.Now().In(loc)
+	}
+	return t.In(loc)
+}
+
+// truncateDay returns midnight of the day of the time passed, in the location passed.
+func truncateDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// parseWholeNumbers parses the values submitted for a group of slider or text input elements into whole numbers. The
+// values of sliders are rounded to the nearest whole number, but text typed in an input that is not a whole number
+// results in an error.
+func parseWholeNumbers(values []any) ([]int, error) {
+	numbers := make([]int, len(values))
+	for i, v := range values {
+		n, err := parseNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := v.(string); ok {
+			// Text typed in an input must be a whole number itself.
+			if n != math.Trunc(n) {
+				return nil, fmt.Errorf("value %v is not a whole number", v)
+			}
+		} else {
+			// Clients submit the value of a slider as a float, which may not be exactly a whole number.
+			n = math.Round(n)
+		}
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, fmt.Errorf("value %v is not a whole number", v)
+		}
+		numbers[i] = int(n)
+	}
+	return numbers, nil
+}
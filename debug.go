@@ -0,0 +1,195 @@
+//go:build formdebug
+
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+	"unicode/utf8"
+)
+
+// Debugger is a set of forms used to debug the forms of a server in-game. It allows a privileged player to browse
+// the forms in a Registry, send any of them to themselves, view the JSON of the last form sent and the last response
+// received and toggle logging of all payloads and responses. The Debugger is only available when building with the
+// 'formdebug' build tag. It is the responsibility of the caller to only send the Debugger to privileged players.
+type Debugger struct {
+	// Registry is the registry browsed through the debugger.
+	Registry *Registry
+	// Log is called with every payload and response of a form wrapped using Wrap while logging is enabled. It may be
+	// nil, in which case nothing is logged.
+	Log func(format string, a ...any)
+
+	mu              sync.Mutex
+	logging         bool
+	payload, answer []byte
+}
+
+// debugPageSize is the maximum amount of bytes of JSON displayed on a single page of the Debugger.
+const debugPageSize = 1024
+
+// Wrap wraps a form so that its payload and responses are recorded by the debugger. Forms looked up in the Registry
+// of the debugger and sent through the debugger are wrapped automatically.
+func (d *Debugger) Wrap(f form.Form) form.Form {
+	return debugForm{Form: f, d: d}
+}
+
+// Menu returns the main menu of the debugger.
+func (d *Debugger) Menu() form.Form {
+	return debugMenu{d: d}
+}
+
+// debugMenu is the main menu of a Debugger.
+type debugMenu struct {
+	d *Debugger
+}
+
+// MarshalJSON ...
+func (m debugMenu) MarshalJSON() ([]byte, error) {
+	return m.d.menu(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (m debugMenu) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return m.d.menu(submitter).SubmitJSON(data, submitter)
+}
+
+// menu returns the main menu of the debugger, of which the buttons send the next form to the submitter passed.
+func (d *Debugger) menu(submitter form.Submitter) *Menu {
+	d.mu.Lock()
+	logging := "§cOff"
+	if d.logging {
+		logging = "§aOn"
+	}
+	d.mu.Unlock()
+	return &Menu{Title: "Form debugger", Buttons: []Button{
+		{Text: "Browse forms", Submit: func() {
+			submitter.SendForm(d.browse(submitter))
+		}},
+		{Text: "Last payload", Submit: func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			submitter.SendForm(d.page(submitter, "Last payload", d.payload, 0))
+		}},
+		{Text: "Last response", Submit: func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			submitter.SendForm(d.page(submitter, "Last response", d.answer, 0))
+		}},
+		{Text: "Debug logging: " + logging, Submit: func() {
+			d.mu.Lock()
+			d.logging = !d.logging
+			d.mu.Unlock()
+			submitter.SendForm(d.Menu())
+		}},
+	}}
+}
+
+// browse returns a menu listing all forms in the Registry of the debugger. Clicking one of the forms sends it to the
+// submitter passed.
+func (d *Debugger) browse(submitter form.Submitter) *Menu {
+	m := &Menu{Title: "Forms", Submit: func(closed bool) {
+		if closed {
+			submitter.SendForm(d.Menu())
+		}
+	}}
+	if d.Registry == nil {
+		m.Content = "No registry set."
+		return m
+	}
+	for _, id := range d.Registry.IDs() {
+		id := id
+		m.AddButtons(Button{Text: id, Submit: func() {
+			if f, ok := d.Registry.Form(id); ok {
+				submitter.SendForm(d.Wrap(f))
+			}
+		}})
+	}
+	return m
+}
+
+// page returns a menu displaying a page of the JSON passed, with buttons to go to the previous or next page.
+func (d *Debugger) page(submitter form.Submitter, title string, data []byte, n int) *Menu {
+	var buf bytes.Buffer
+	if len(data) == 0 || json.Indent(&buf, data, "", "  ") != nil {
+		buf.Reset()
+		buf.Write(data)
+	}
+	text := buf.Bytes()
+	pages := (len(text) + debugPageSize - 1) / debugPageSize
+	start, end := n*debugPageSize, (n+1)*debugPageSize
+	if end > len(text) {
+		end = len(text)
+	}
+	// Make sure pages are not split in the middle of a UTF-8 encoded character.
+	for start > 0 && start < len(text) && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	m := &Menu{Title: fmt.Sprintf("%v (%v/%v)", title, n+1, pages), Content: string(text[start:end]), Submit: func(closed bool) {
+		if closed {
+			submitter.SendForm(d.Menu())
+		}
+	}}
+	if len(text) == 0 {
+		m.Content = "Nothing recorded yet."
+	}
+	if n > 0 {
+		m.AddButtons(Button{Text: "Previous page", Submit: func() {
+			submitter.SendForm(d.page(submitter, title, data, n-1))
+		}})
+	}
+	if n < pages-1 {
+		m.AddButtons(Button{Text: "Next page", Submit: func() {
+			submitter.SendForm(d.page(submitter, title, data, n+1))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		submitter.SendForm(d.Menu())
+	}})
+	return m
+}
+
+// record records a payload or response of a form and logs it if logging is enabled.
+func (d *Debugger) record(data []byte, response bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	kind := "payload"
+	if response {
+		kind, d.answer = "response", append([]byte(nil), data...)
+	} else {
+		d.payload = append([]byte(nil), data...)
+	}
+	if d.logging && d.Log != nil {
+		d.Log("form %v: %s", kind, data)
+	}
+}
+
+// debugForm is a form wrapped by a Debugger, which records its payload and responses.
+type debugForm struct {
+	form.Form
+	d *Debugger
+}
+
+// MarshalJSON ...
+func (f debugForm) MarshalJSON() ([]byte, error) {
+	data, err := f.Form.MarshalJSON()
+	if err == nil {
+		f.d.record(data, false)
+	}
+	return data, err
+}
+
+// SubmitJSON ...
+func (f debugForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if data == nil {
+		f.d.record([]byte("null"), true)
+	} else {
+		f.d.record(data, true)
+	}
+	return f.Form.SubmitJSON(data, submitter)
+}
@@ -0,0 +1,91 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+)
+
+// DismissalStore persists the forms that players chose not to be shown again. Players are identified by a key, such
+// as their UUID, and forms by an ID.
+type DismissalStore interface {
+	// Dismissed checks if the player with the key passed chose not to be shown the form with the ID passed again.
+	Dismissed(key, id string) bool
+	// Dismiss stores that the player with the key passed chose not to be shown the form with the ID passed again.
+	Dismiss(key, id string)
+}
+
+// MemoryDismissalStore is a DismissalStore that keeps dismissals in memory. The zero value of a MemoryDismissalStore
+// is ready to use. A MemoryDismissalStore is safe for concurrent use.
+type MemoryDismissalStore struct {
+	mu sync.Mutex
+	m  map[[2]string]struct{}
+}
+
+// Dismissed ...
+func (s *MemoryDismissalStore) Dismissed(key, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.m[[2]string{key, id}]
+	return ok
+}
+
+// Dismiss ...
+func (s *MemoryDismissalStore) Dismiss(key, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[[2]string]struct{})
+	}
+	s.m[[2]string{key, id}] = struct{}{}
+}
+
+// Dismissible sends forms that players may choose not to be shown again, such as tips or announcements. A toggle is
+// added to the bottom of Custom forms, and a button to the bottom of Menus. Once a player chose not to be shown a form
+// again, sending it to that player is skipped.
+type Dismissible struct {
+	// Store is the store in which the dismissals of players are persisted.
+	Store DismissalStore
+	// Text is the text of the toggle or button added to forms. If empty, "Don't show again" is used.
+	Text string
+}
+
+// Send sends the form passed with the ID passed to the submitter passed, which is the player with the key passed. If
+// the player chose not to be shown the form again, the form is not sent, but the fallback passed is called instead,
+// if not nil, and Send returns false. Forms other than *Custom and *Menu are sent without the option to dismiss them.
+func (d Dismissible) Send(submitter form.Submitter, key, id string, f form.Form, fallback func()) bool {
+	if d.Store.Dismissed(key, id) {
+		if fallback != nil {
+			fallback()
+		}
+		return false
+	}
+	submitter.SendForm(d.Wrap(key, id, f))
+	return true
+}
+
+// Wrap returns the form passed with the option to dismiss it added for the player with the key passed, without checking
+// if the player dismissed it already. It may be used to send a form that is both dismissible and cached, by passing
+// the form returned to ResponseCache.Send. Forms other than *Custom and *Menu are returned unchanged.
+func (d Dismissible) Wrap(key, id string, f form.Form) form.Form {
+	text := d.Text
+	if text == "" {
+		text = "Don't show again"
+	}
+	dismiss := func() {
+		d.Store.Dismiss(key, id)
+	}
+	switch f := f.(type) {
+	case *Custom:
+		return trailingToggle{Form: f, text: text, submit: func(dismissed bool, _ []byte) {
+			if dismissed {
+				dismiss()
+			}
+		}}
+	case *Menu:
+		m := *f
+		m.Elements = append([]MenuElement(nil), f.Elements...)
+		m.Buttons = append(append([]Button(nil), f.Buttons...), Button{Text: text, Image: TextureCancel, Submit: dismiss})
+		return &m
+	}
+	return f
+}
@@ -0,0 +1,107 @@
+package form
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationInput represents a text input box element in which a duration must be filled out, such as '1h30m' or
+// '2d'. Durations are parsed like time.ParseDuration does, with the additional units 'd' for days and 'w' for weeks.
+// Submitters cannot submit text that is not a duration, or a duration outside the range of the input.
+type DurationInput struct {
+	// Text is the text displayed over the input element. The text may contain Minecraft formatting codes.
+	Text string
+	// Default is the default duration filled out in the input. If zero, no duration is filled out.
+	Default time.Duration
+	// Placeholder is the text displayed in the input box if it does not contain any text filled out by the user.
+	Placeholder string
+	// Min and Max are used to specify the minimum and maximum duration that may be submitted. If Max is zero, there is
+	// no maximum duration.
+	Min, Max time.Duration
+	// Submit is called with the duration provided by the player whenever they submit the form. If the form is closed,
+	// this method is not called. This is always called before the Form's Submit.
+	Submit func(d time.Duration)
+}
+
+// MarshalJSON ...
+func (i DurationInput) MarshalJSON() ([]byte, error) {
+	var def string
+	if i.Default != 0 {
+		def = i.Default.String()
+	}
+	return Input{Text: i.Text, Default: def, Placeholder: i.Placeholder}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (i DurationInput) SubmitValue(value any) error {
+	if i.Submit == nil {
+		return nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("value %v is not allowed for input element", value)
+	}
+	d, err := ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	if d < i.Min || (i.Max != 0 && d > i.Max) {
+		return fmt.Errorf("duration %v is out of range %v-%v", d, i.Min, i.Max)
+	}
+	i.Submit(d)
+	return nil
+}
+
+// durationUnits maps the units accepted by ParseDuration to their duration.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration like time.ParseDuration, but additionally accepts the units 'd' for days and 'w'
+// for weeks, such as in '2d' or '1w3d12h'. Spaces between the parts of the duration are ignored. Unlike
+// time.ParseDuration, negative durations are not accepted.
+func ParseDuration(s string) (time.Duration, error) {
+	text := strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if text == "" {
+		return 0, fmt.Errorf("duration may not be empty")
+	} else if text == "0" {
+		return 0, nil
+	}
+	var total float64
+	for text != "" {
+		i := strings.IndexFunc(text, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.ParseFloat(text[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		text = text[i:]
+		j := strings.IndexFunc(text, func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' })
+		if j == -1 {
+			j = len(text)
+		}
+		unit, ok := durationUnits[text[:j]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q in duration %q", text[:j], s)
+		}
+		text = text[j:]
+		total += n * float64(unit)
+	}
+	if total >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too long", s)
+	}
+	return time.Duration(total), nil
+}
@@ -3,15 +3,40 @@ package form
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"math"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 )
 
 // Element represents an element that may be added to a Form. Any of the types in this package that implement
-// the element interface may be added to a form before it is sent to a player.
+// the element interface may be added to a form before it is sent to a player. Types outside this package may also
+// implement Element to add their own kind of element to Custom forms.
 type Element interface {
+	// MarshalJSON encodes the element as an object in the 'content' of a Custom form.
 	json.Marshaler
-	submit(value any) error
+	// SubmitValue is called with the value submitted by a player for the element when the Custom form it was added to
+	// is submitted. The value is decoded from JSON, with numbers decoded as json.Number. SubmitValue should return an
+	// error if the value is not valid for the element, in which case the form is not submitted any further. It is
+	// always called before the Custom form's Submit.
+	SubmitValue(value any) error
+}
+
+// normalizer is an Element that corrects the value submitted for it before it is accepted, such as by truncating the
+// text of an Input. A Custom form passes the value returned by normalize to its Submit instead of the value submitted.
+type normalizer interface {
+	// normalize validates the value submitted for the element and returns the value as it is accepted.
+	normalize(value any) (any, error)
+}
+
+// composite is an Element that is made up of multiple underlying elements. A Custom form expands a composite into the
+// elements it is made up of when it is marshaled, and submits the values of all of those elements to the composite
+// at once, as a []any.
+type composite interface {
+	Element
+	elements() []Element
 }
 
 // Label represents a static label on a form. It serves only to display a box of text, and users cannot
@@ -29,11 +54,72 @@ func (l Label) MarshalJSON() ([]byte, error) {
 	})
 }
 
-// Submit ...
-func (l Label) submit(any) error {
+// SubmitValue ...
+func (l Label) SubmitValue(any) error {
 	return nil
 }
 
+// Clickable ...
+func (Label) Clickable() bool {
+	return false
+}
+
+// Click ...
+func (Label) Click() {}
+
+// Header represents a static header on a form. It displays text that is larger and bolder than that of a Label,
+// which may be used to separate a form into sections. Users cannot submit values to it.
+type Header struct {
+	// Text is the text held by the header. The text may contain Minecraft formatting codes.
+	Text string
+}
+
+// MarshalJSON ...
+func (h Header) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type": "header",
+		"text": h.Text,
+	})
+}
+
+// SubmitValue ...
+func (h Header) SubmitValue(any) error {
+	return nil
+}
+
+// Clickable ...
+func (Header) Clickable() bool {
+	return false
+}
+
+// Click ...
+func (Header) Click() {}
+
+// Divider represents a horizontal line on a form, which may be used to separate a form into sections. Users cannot
+// submit values to it: Clients submit a null value for it.
+type Divider struct{}
+
+// MarshalJSON ...
+func (Divider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type": "divider",
+		"text": "",
+	})
+}
+
+// SubmitValue ...
+func (Divider) SubmitValue(any) error {
+	return nil
+}
+
+// Clickable ...
+func (Divider) Clickable() bool {
+	return false
+}
+
+// Click ...
+func (Divider) Click() {}
+
 // Input represents a text input box element. Submitters may write any text in these boxes with no specific
 // length.
 type Input struct {
@@ -45,6 +131,15 @@ type Input struct {
 	// Placeholder is the text displayed in the input box if it does not contain any text filled out by the
 	// user. The text may contain Minecraft formatting codes.
 	Placeholder string
+	// MaxLength is the maximum length of the text submitted, in characters. If zero, text of any length may be
+	// submitted.
+	MaxLength int
+	// Truncate specifies if text longer than MaxLength should be truncated to MaxLength characters. If false, text
+	// longer than MaxLength is rejected.
+	Truncate bool
+	// Pattern is a regular expression that the text submitted must match. If nil, any text may be submitted. Note
+	// that the pattern matches any part of the text unless it is anchored using ^ and $.
+	Pattern *regexp.Regexp
 	// Submit is called with the value provided by the player whenever they submit the form. If the form is closed, this
 	// method is not called. This is always called before the Form's Submit.
 	Submit func(text string)
@@ -60,19 +155,42 @@ func (i Input) MarshalJSON() ([]byte, error) {
 	})
 }
 
-// Submit ...
-func (i Input) submit(value any) error {
-	if i.Submit == nil {
-		return nil
+// SubmitValue ...
+func (i Input) SubmitValue(value any) error {
+	text, err := i.accept(value)
+	if err != nil {
+		return err
+	}
+	if i.Submit != nil {
+		i.Submit(text)
 	}
+	return nil
+}
+
+// normalize ...
+func (i Input) normalize(value any) (any, error) {
+	return i.accept(value)
+}
+
+// accept validates the value submitted for the input and returns the text accepted, truncated to the MaxLength if
+// Truncate is set.
+func (i Input) accept(value any) (string, error) {
 	text, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("value %v is not allowed for input element", value)
+		return "", fmt.Errorf("value %v is not allowed for input element", value)
 	} else if !utf8.ValidString(text) {
-		return fmt.Errorf("value %v is not valid UTF8", value)
+		return "", fmt.Errorf("value %v is not valid UTF8", value)
 	}
-	i.Submit(text)
-	return nil
+	if n := utf8.RuneCountInString(text); i.MaxLength > 0 && n > i.MaxLength {
+		if !i.Truncate {
+			return "", fmt.Errorf("input text is %v characters long, exceeding the maximum length of %v", n, i.MaxLength)
+		}
+		text = string([]rune(text)[:i.MaxLength])
+	}
+	if i.Pattern != nil && !i.Pattern.MatchString(text) {
+		return "", fmt.Errorf("input text %q does not match pattern %v", text, i.Pattern)
+	}
+	return text, nil
 }
 
 // Toggle represents an on-off button element. Submitters may either toggle this on or off, which will then
@@ -83,6 +201,9 @@ type Toggle struct {
 	// Default is the default value filled out in the input. The user may remove this value and fill out its
 	// own text. The text may contain Minecraft formatting codes.
 	Default bool
+	// Tooltip is the tooltip displayed when hovering over the element, on clients that support it. If empty, no tooltip
+	// is displayed. Clients that do not support tooltips ignore it.
+	Tooltip string
 	// Submit is called with the value provided by the player whenever they submit the form. If the form is closed, this
 	// method is not called. This is always called before the Form's Submit.
 	Submit func(enabled bool)
@@ -90,23 +211,22 @@ type Toggle struct {
 
 // MarshalJSON ...
 func (t Toggle) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	return json.Marshal(withTooltip(map[string]any{
 		"type":    "toggle",
 		"text":    t.Text,
 		"default": t.Default,
-	})
+	}, t.Tooltip))
 }
 
-// Submit ...
-func (t Toggle) submit(value any) error {
-	if t.Submit == nil {
-		return nil
-	}
+// SubmitValue ...
+func (t Toggle) SubmitValue(value any) error {
 	enabled, ok := value.(bool)
 	if !ok {
 		return fmt.Errorf("value %v is not allowed for toggle element", value)
 	}
-	t.Submit(enabled)
+	if t.Submit != nil {
+		t.Submit(enabled)
+	}
 	return nil
 }
 
@@ -123,6 +243,12 @@ type Slider struct {
 	StepSize float64
 	// Default is the default value filled out for the slider.
 	Default float64
+	// Steps specifies how submitted values that do not lie on a step of the slider are handled. By default, such values
+	// are submitted as is.
+	Steps StepMode
+	// Tooltip is the tooltip displayed when hovering over the element, on clients that support it. If empty, no tooltip
+	// is displayed. Clients that do not support tooltips ignore it.
+	Tooltip string
 	// Submit is called with the value provided by the player whenever they submit the form. If the form is closed, this
 	// method is not called. This is always called before the Form's Submit.
 	Submit func(value float64)
@@ -130,32 +256,123 @@ type Slider struct {
 
 // MarshalJSON ...
 func (s Slider) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	return json.Marshal(withTooltip(map[string]any{
 		"type":    "slider",
 		"text":    s.Text,
 		"min":     s.Min,
 		"max":     s.Max,
 		"step":    s.StepSize,
 		"default": s.Default,
-	})
+	}, s.Tooltip))
 }
 
-// Submit ...
-func (s Slider) submit(value any) error {
-	if s.Submit == nil {
-		return nil
+// SubmitValue ...
+func (s Slider) SubmitValue(value any) error {
+	val, err := s.accept(value)
+	if err != nil {
+		return err
 	}
-	number, ok := value.(json.Number)
-	val, err := number.Float64()
-	if !ok || err != nil {
-		return fmt.Errorf("value %v is not allowed for toggle element", value)
+	if s.Submit != nil {
+		s.Submit(val)
+	}
+	return nil
+}
+
+// normalize ...
+func (s Slider) normalize(value any) (any, error) {
+	return s.accept(value)
+}
+
+// accept validates the value submitted for the slider and returns the value accepted, aligned to a step according to
+// the Steps of the slider.
+func (s Slider) accept(value any) (float64, error) {
+	val, err := parseSliderValue("slider", value)
+	if err != nil {
+		return 0, err
 	} else if val < s.Min || val > s.Max {
+		return 0, fmt.Errorf("slider value %v is out of range %v-%v", val, s.Min, s.Max)
+	}
+	if s.StepSize > 0 && s.Steps != StepsAny {
+		// Clients submit the value as a float, so a small error is allowed before a value is considered off-step.
+		steps := (val - s.Min) / s.StepSize
+		aligned := math.Round(steps)
+		if s.Steps == StepsStrict && math.Abs(steps-aligned) > 1e-6 {
+			return 0, fmt.Errorf("slider value %v is not on a step of size %v", val, s.StepSize)
+		}
+		val = math.Min(s.Min+aligned*s.StepSize, s.Max)
+	}
+	return val, nil
+}
+
+// StepMode specifies how a Slider handles submitted values that do not lie on one of its steps.
+type StepMode int
+
+const (
+	// StepsAny submits values as they were submitted by the client, even if they do not lie on a step.
+	StepsAny StepMode = iota
+	// StepsSnap rounds values to the nearest step.
+	StepsSnap
+	// StepsStrict rejects values that do not lie on a step. Values that are off by a rounding error only are rounded to
+	// the step.
+	StepsStrict
+)
+
+// IntSlider represents a slider element used to select whole numbers. Unlike a Slider, it submits an int, and only
+// accepts values that lie on one of its steps.
+type IntSlider struct {
+	// Text is the text displayed over the slider element. The text may contain Minecraft formatting codes.
+	Text string
+	// Min and Max are used to specify the minimum and maximum range of the slider. A value lower or higher
+	// than these values cannot be selected.
+	Min, Max int
+	// StepSize is the size that one step of the slider takes up. If zero, a step size of 1 is used.
+	StepSize int
+	// Default is the default value filled out for the slider.
+	Default int
+	// Submit is called with the value provided by the player whenever they submit the form. If the form is closed, this
+	// method is not called. This is always called before the Form's Submit.
+	Submit func(value int)
+}
+
+// MarshalJSON ...
+func (s IntSlider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":    "slider",
+		"text":    s.Text,
+		"min":     s.Min,
+		"max":     s.Max,
+		"step":    s.step(),
+		"default": s.Default,
+	})
+}
+
+// SubmitValue ...
+func (s IntSlider) SubmitValue(value any) error {
+	f, err := parseSliderValue("int slider", value)
+	if err != nil {
+		return err
+	}
+	// Clients submit the value of a slider as a float, which may not be exactly a whole number.
+	val := int(math.Round(f))
+	if val < s.Min || val > s.Max {
 		return fmt.Errorf("slider value %v is out of range %v-%v", val, s.Min, s.Max)
+	} else if (val-s.Min)%s.step() != 0 {
+		return fmt.Errorf("slider value %v is not on a step of size %v", val, s.step())
+	}
+	if s.Submit != nil {
+		s.Submit(val)
 	}
-	s.Submit(val)
 	return nil
 }
 
+// step returns the step size of the slider.
+func (s IntSlider) step() int {
+	if s.StepSize <= 0 {
+		return 1
+	}
+	return s.StepSize
+}
+
 // Dropdown represents a dropdown which, when clicked, opens a window with the options set in the Options
 // field. Submitters may select one of the options.
 type Dropdown struct {
@@ -167,6 +384,13 @@ type Dropdown struct {
 	// DefaultIndex is the index in the Options slice that is used as default. When sent to a Submitter, the
 	// value at this index in the Options slice will be selected.
 	DefaultIndex int
+	// DefaultOption is the option that is used as default. If not empty, it takes precedence over DefaultIndex and is
+	// resolved to the index of the first option equal to it when the element is marshaled. Marshaling fails if none
+	// of the Options equal DefaultOption.
+	DefaultOption string
+	// Tooltip is the tooltip displayed when hovering over the element, on clients that support it. If empty, no tooltip
+	// is displayed. Clients that do not support tooltips ignore it.
+	Tooltip string
 	// Submit is called with the value provided by the player whenever they submit the form. If the form is closed, this
 	// method is not called. This is always called before the Form's Submit.
 	Submit func(index int, option string)
@@ -174,19 +398,28 @@ type Dropdown struct {
 
 // MarshalJSON ...
 func (d Dropdown) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	def, err := defaultIndex(d.Options, d.DefaultIndex, d.DefaultOption)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(withTooltip(map[string]any{
 		"type":    "dropdown",
 		"text":    d.Text,
-		"default": d.DefaultIndex,
+		"default": def,
 		"options": d.Options,
-	})
+	}, d.Tooltip))
 }
 
-// Submit ...
-func (d Dropdown) submit(value any) error {
-	if d.Submit == nil {
-		return nil
+// withTooltip adds the tooltip passed to the JSON object of an element if it is not empty.
+func withTooltip(m map[string]any, tooltip string) map[string]any {
+	if tooltip != "" {
+		m["tooltip"] = tooltip
 	}
+	return m
+}
+
+// SubmitValue ...
+func (d Dropdown) SubmitValue(value any) error {
 	number, ok := value.(json.Number)
 	val, err := number.Int64()
 	if !ok || err != nil {
@@ -195,7 +428,9 @@ func (d Dropdown) submit(value any) error {
 	if val < 0 || int(val) >= len(d.Options) {
 		return fmt.Errorf("dropdown value %v is out of range %v-%v", val, 0, len(d.Options)-1)
 	}
-	d.Submit(int(val), d.Options[val])
+	if d.Submit != nil {
+		d.Submit(int(val), d.Options[val])
+	}
 	return nil
 }
 
@@ -205,31 +440,88 @@ type StepSlider Dropdown
 
 // MarshalJSON ...
 func (s StepSlider) MarshalJSON() ([]byte, error) {
+	def, err := defaultIndex(s.Options, s.DefaultIndex, s.DefaultOption)
+	if err != nil {
+		return nil, err
+	}
 	return json.Marshal(map[string]any{
 		"type":    "step_slider",
 		"text":    s.Text,
-		"default": s.DefaultIndex,
+		"default": def,
 		"steps":   s.Options,
 	})
 }
 
-// Submit ...
-func (s StepSlider) submit(value any) error {
-	if s.Submit == nil {
-		return nil
+// SubmitValue ...
+func (s StepSlider) SubmitValue(value any) error {
+	f, err := parseSliderValue("step slider", value)
+	if err != nil {
+		return err
+	} else if f != math.Trunc(f) {
+		return &InvalidNumberError{Element: "step slider", Value: value, Reason: "not a whole number"}
 	}
-	number, ok := value.(json.Number)
-	val, err := number.Int64()
-	if !ok || err != nil {
-		return fmt.Errorf("value %v is not allowed for step slider element", value)
+	if f < 0 || f >= float64(len(s.Options)) {
+		return fmt.Errorf("step slider value %v is out of range %v-%v", f, 0, len(s.Options)-1)
 	}
-	if val < 0 || int(val) >= len(s.Options) {
-		return fmt.Errorf("dropdown value %v is out of range %v-%v", val, 0, len(s.Options)-1)
+	if s.Submit != nil {
+		s.Submit(int(f), s.Options[int(f)])
 	}
-	s.Submit(int(val), s.Options[val])
 	return nil
 }
 
+// maxNumber is the largest absolute value accepted for sliders and step sliders. Larger values are never submitted by
+// legitimate clients and may lose precision or overflow when converted.
+const maxNumber = 1 << 53
+
+// InvalidNumberError is returned when a player submits a value for a slider or step slider that is not a valid
+// number, such as NaN, infinity or a number too large to be submitted by a legitimate client.
+type InvalidNumberError struct {
+	// Element is the type of the element that the value was submitted for, such as 'slider'.
+	Element string
+	// Value is the value submitted.
+	Value any
+	// Reason describes why the value is not valid.
+	Reason string
+}
+
+// Error ...
+func (e *InvalidNumberError) Error() string {
+	return fmt.Sprintf("value %v is not allowed for %v element: %v", e.Value, e.Element, e.Reason)
+}
+
+// parseSliderValue parses the value submitted for a slider or step slider element of the type passed. It returns an
+// *InvalidNumberError if the value is not a json.Number, is NaN or infinite, or is too large.
+func parseSliderValue(element string, value any) (float64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, &InvalidNumberError{Element: element, Value: value, Reason: "not a number"}
+	}
+	f, err := number.Float64()
+	switch {
+	case math.IsNaN(f):
+		return 0, &InvalidNumberError{Element: element, Value: value, Reason: "NaN"}
+	case err != nil && !math.IsInf(f, 0):
+		return 0, &InvalidNumberError{Element: element, Value: value, Reason: "malformed number"}
+	case math.IsInf(f, 0) || math.Abs(f) > maxNumber:
+		return 0, &InvalidNumberError{Element: element, Value: value, Reason: "number too large"}
+	}
+	return f, nil
+}
+
+// defaultIndex returns the index of the default option of a Dropdown or StepSlider. If option is not empty, the index
+// of the first option equal to it is returned, or an error if no option is equal to it. Otherwise, index is returned.
+func defaultIndex(options []string, index int, option string) (int, error) {
+	if option == "" {
+		return index, nil
+	}
+	for i, o := range options {
+		if o == option {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("default option %q is not one of the options %q", option, options)
+}
+
 // Button represents a button added to a Menu or Modal form. The button has text on it and an optional image,
 // which may be either retrieved from a website or the local assets of the game.
 type Button struct {
@@ -238,15 +530,53 @@ type Button struct {
 	Text string
 	// Image holds a path to an image for the button. The Image may either be a URL pointing to an image,
 	// such as 'https://someimagewebsite.com/someimage.png', or a path pointing to a local asset, such as
-	// 'textures/blocks/grass_carried'.
+	// 'textures/blocks/grass_carried'. Images that are malformed cause the form to fail to encode, as checked by
+	// ValidateImage.
 	Image string
 	// Submit is called when a player clicks on the button in a form. This is always called before the Form's Submit.
 	Submit func()
+	// Visible returns if the button is visible to the submitter passed, such as to hide buttons that require a
+	// permission. Buttons of a Menu that are not visible are removed by Menu.For, and clicking them is rejected when
+	// the Menu is submitted. If nil, the button is visible to every submitter.
+	Visible func(submitter form.Submitter) bool
+	// Disabled specifies if the button is disabled. A disabled button is displayed greyed out, and clicking it does not
+	// call its Submit or the Submit of the form, but calls Unavailable instead.
+	Disabled bool
+	// Unavailable is called when a player clicks the button while it is Disabled, such as to tell the player why the
+	// button is not available. If nil, clicking a disabled button is rejected with an error when the form is submitted.
+	Unavailable func()
+}
+
+// visible checks if the button is visible to the submitter passed.
+func (b Button) visible(submitter form.Submitter) bool {
+	return b.Visible == nil || b.Visible(submitter)
+}
+
+// text returns the text displayed on the button. The text of a disabled button is stripped of its formatting codes
+// and greyed out.
+func (b Button) text() string {
+	if b.Disabled {
+		return "§8" + strings.ReplaceAll(text.Clean(b.Text), "\n", "\n§8")
+	}
+	return b.Text
+}
+
+// unavailable handles a click on the button while it is disabled. It returns an error if the button has no
+// Unavailable function.
+func (b Button) unavailable() error {
+	if b.Unavailable == nil {
+		return fmt.Errorf("button is disabled")
+	}
+	b.Unavailable()
+	return nil
 }
 
 // MarshalJSON ...
 func (b Button) MarshalJSON() ([]byte, error) {
-	m := map[string]any{"text": b.Text}
+	if err := ValidateImage(b.Image); err != nil {
+		return nil, err
+	}
+	m := map[string]any{"text": b.text()}
 	if b.Image != "" {
 		buttonType := "path"
 		if strings.HasPrefix(b.Image, "http:") || strings.HasPrefix(b.Image, "https:") {
@@ -256,3 +586,43 @@ func (b Button) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(m)
 }
+
+// Clickable ...
+func (Button) Clickable() bool {
+	return true
+}
+
+// Click ...
+func (b Button) Click() {
+	if b.Submit != nil {
+		b.Submit()
+	}
+}
+
+// RawElement represents an element of which the JSON is provided directly. It may be used to add kinds of elements
+// to a Custom form that are supported by the client, but not yet by this package.
+type RawElement struct {
+	// JSON is the JSON object of the element as sent to the client, including its 'type' field.
+	JSON json.RawMessage
+	// Submit is called with the value provided by the player whenever they submit the form. The value is decoded from
+	// JSON, with numbers decoded as json.Number. If Submit returns an error, the form is not submitted any further. If
+	// the form is closed, this method is not called. This is always called before the Form's Submit. If nil, any value
+	// is accepted, as the value of an element of a kind unknown to this package cannot be validated otherwise.
+	Submit func(value any) error
+}
+
+// MarshalJSON ...
+func (r RawElement) MarshalJSON() ([]byte, error) {
+	if len(r.JSON) == 0 {
+		return nil, fmt.Errorf("raw element has no JSON")
+	}
+	return r.JSON, nil
+}
+
+// SubmitValue ...
+func (r RawElement) SubmitValue(value any) error {
+	if r.Submit == nil {
+		return nil
+	}
+	return r.Submit(value)
+}
@@ -0,0 +1,141 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// Rating represents a step slider used to give a rating of a number of stars, such as for a survey.
+type Rating struct {
+	// Text is the text displayed over the rating. The text may contain Minecraft formatting codes.
+	Text string
+	// Stars is the maximum amount of stars that may be given. If zero, ratings of up to 5 stars may be given.
+	Stars int
+	// Default is the amount of stars selected by default. If zero, one star is selected.
+	Default int
+	// Submit is called with the amount of stars given by the player, starting at 1, whenever they submit the form. If
+	// the form is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(stars int)
+}
+
+// MarshalJSON ...
+func (r Rating) MarshalJSON() ([]byte, error) {
+	return r.slider().MarshalJSON()
+}
+
+// SubmitValue ...
+func (r Rating) SubmitValue(value any) error {
+	return r.slider().SubmitValue(value)
+}
+
+// slider returns the StepSlider that the rating is sent as.
+func (r Rating) slider() StepSlider {
+	stars := r.Stars
+	if stars <= 0 {
+		stars = 5
+	}
+	options := make([]string, stars)
+	for i := range options {
+		options[i] = "§e" + strings.Repeat("★", i+1) + "§7" + strings.Repeat("☆", stars-i-1)
+	}
+	def := r.Default - 1
+	if def < 0 || def >= stars {
+		def = 0
+	}
+	slider := StepSlider{Text: r.Text, Options: options, DefaultIndex: def}
+	if r.Submit != nil {
+		slider.Submit = func(index int, _ string) {
+			r.Submit(index + 1)
+		}
+	}
+	return slider
+}
+
+// Feedback is the feedback submitted by a player using a FeedbackForm.
+type Feedback struct {
+	// Submitter is the submitter that submitted the feedback.
+	Submitter form.Submitter
+	// Topic is the Topic of the FeedbackForm that the feedback was submitted for.
+	Topic string
+	// Stars is the amount of stars given.
+	Stars int
+	// Comment is the comment left by the player. It is empty if the player left no comment.
+	Comment string
+}
+
+// FeedbackSink publishes feedback submitted using a FeedbackForm, such as to a webhook or a database.
+type FeedbackSink interface {
+	// Publish publishes the feedback passed.
+	Publish(f Feedback) error
+}
+
+// FeedbackSinkFunc is a function that implements FeedbackSink.
+type FeedbackSinkFunc func(f Feedback) error
+
+// Publish ...
+func (fn FeedbackSinkFunc) Publish(f Feedback) error {
+	return fn(f)
+}
+
+// FeedbackForm represents a ready-made form used to ask players for feedback, such as after an event. It consists of
+// a Rating and an optional comment input. The feedback submitted is published to all Sinks.
+type FeedbackForm struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Topic is the topic that feedback is asked for, such as the name of the event. It is passed with the Feedback.
+	Topic string
+	// Question is the question displayed over the rating. If empty, 'How would you rate {topic}?' is displayed.
+	Question string
+	// Comment specifies if the player may leave a comment with its rating.
+	Comment bool
+	// MaxCommentLength is the maximum length of the comment. If zero, comments of up to 256 characters may be left.
+	MaxCommentLength int
+	// Sinks holds the sinks that the feedback submitted is published to.
+	Sinks []FeedbackSink
+	// Error is called with every error returned by one of the Sinks. It may be nil.
+	Error func(err error)
+}
+
+// MarshalJSON ...
+func (f *FeedbackForm) MarshalJSON() ([]byte, error) {
+	return f.custom(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (f *FeedbackForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return f.custom(submitter).SubmitJSON(data, submitter)
+}
+
+// custom returns the Custom form of the feedback form. Submitting it publishes the feedback of the submitter passed.
+func (f *FeedbackForm) custom(submitter form.Submitter) *Custom {
+	feedback := Feedback{Submitter: submitter, Topic: f.Topic}
+	question := f.Question
+	if question == "" {
+		question = fmt.Sprintf("How would you rate %v§r?", f.Topic)
+	}
+	elements := []Element{Rating{Text: question, Default: 5, Submit: func(stars int) {
+		feedback.Stars = stars
+	}}}
+	if f.Comment {
+		max := f.MaxCommentLength
+		if max <= 0 {
+			max = 256
+		}
+		elements = append(elements, Input{Text: "Comment (optional)", MaxLength: max, Truncate: true,
+			Submit: func(text string) {
+				feedback.Comment = strings.TrimSpace(text)
+			},
+		})
+	}
+	return &Custom{Title: f.Title, Elements: elements, Submit: func(closed bool, _ []any) {
+		if closed {
+			return
+		}
+		for _, sink := range f.Sinks {
+			if err := sink.Publish(feedback); err != nil && f.Error != nil {
+				f.Error(err)
+			}
+		}
+	}}
+}
@@ -0,0 +1,479 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Flow is a single run of a series of steps, such as a wizard, for a submitter. Every step shows a form to the
+// submitter and produces a result once the form is submitted, which is passed as input to the next step. This allows
+// flows to be written as a series of pure functions, rather than closures that change a shared struct.
+//
+// Closing the form of any step cancels the whole flow, as does calling Cancel. Cleanup functions registered using
+// Defer are run when a flow is cancelled.
+//
+// Every flow keeps a log of the last events that happened during it, such as forms being sent and submitted, which may
+// be obtained using Events to find out how a submitter ended up in a broken state.
+type Flow struct {
+	// Submitter is the submitter that the flow is run for.
+	Submitter form.Submitter
+	// ID is the ID of the flow, as passed to Run.
+	ID string
+
+	mu        sync.Mutex
+	step      int
+	done      bool
+	cancelled bool
+	cleanups  []func()
+	events    []FlowEvent
+
+	store             FlowStore
+	key               string
+	responses, replay [][]byte
+	pending           []byte
+}
+
+// MaxFlowEvents is the maximum amount of events kept in the event log of a Flow. Once a flow has more events, the
+// oldest events are removed from its log.
+const MaxFlowEvents = 128
+
+// FlowEvent is an event in the event log of a Flow.
+type FlowEvent struct {
+	// Time is the time at which the event happened.
+	Time time.Time `json:"time"`
+	// Type is the type of the event: 'send' when the form of a step is sent, 'replay' when the form of a step is
+	// submitted with a saved response, 'submit' when a response is submitted, 'error' when submitting a response
+	// returns an error, 'close' when the form of a step is closed, 'transition' when the flow moves on to the next
+	// step, 'finish' when the flow finishes and 'cancel' when the flow is cancelled.
+	Type string `json:"type"`
+	// Step is the index of the step of the flow at the time of the event.
+	Step int `json:"step"`
+	// Data holds the response submitted for 'submit' and 'replay' events.
+	Data json.RawMessage `json:"data,omitempty"`
+	// Form is the type of the form sent for 'send' events.
+	Form string `json:"form,omitempty"`
+	// Error is the error returned for 'error' events.
+	Error string `json:"error,omitempty"`
+}
+
+// Step is a single step of a Flow. It is called with the result of the previous step, and returns the form shown to
+// the submitter of the flow for this step. The form returned must call next with the result of the step once it is
+// submitted, after which the flow moves on to the next step. If the form is closed, the flow is cancelled.
+type Step[In, Out any] func(f *Flow, in In, next func(out Out)) form.Form
+
+// Then chains two steps into a single step, so that the result of the first step is passed as input to the second
+// step. Flows of more than two steps may be created by chaining the step returned with another step.
+func Then[A, B, C any](first Step[A, B], second Step[B, C]) Step[A, C] {
+	return func(f *Flow, in A, next func(out C)) form.Form {
+		return first(f, in, func(out B) {
+			if !f.next() {
+				return
+			}
+			f.log(FlowEvent{Type: "transition"})
+			f.send(second(f, out, next))
+		})
+	}
+}
+
+// flows holds all flows currently running for comparable submitters, so that they may be cancelled using Cancel.
+var flows = struct {
+	sync.Mutex
+	m map[flowKey]*Flow
+}{m: make(map[flowKey]*Flow)}
+
+// flowKey is the key of a flow in the flows map.
+type flowKey struct {
+	s  form.Submitter
+	id string
+}
+
+// Run runs a flow for the submitter passed, starting with the step passed and the input passed. Once the last step of
+// the flow produces its result, done is called with it. If a flow with the same ID is already running for the
+// submitter, it is cancelled first. Disconnect should be called when the submitter leaves the server, so that flows
+// that were still running are cancelled.
+func Run[In, Out any](submitter form.Submitter, id string, step Step[In, Out], in In, done func(out Out)) *Flow {
+	f := &Flow{Submitter: submitter, ID: id}
+	start(f, step, in, done)
+	return f
+}
+
+// start starts running the flow passed with the step and input passed.
+func start[In, Out any](f *Flow, step Step[In, Out], in In, done func(out Out)) {
+	submitter, id := f.Submitter, f.ID
+	if reflect.TypeOf(submitter).Comparable() {
+		flows.Lock()
+		previous := flows.m[flowKey{s: submitter, id: id}]
+		flows.m[flowKey{s: submitter, id: id}] = f
+		flows.Unlock()
+		if previous != nil {
+			previous.Cancel()
+		}
+	}
+	f.send(step(f, in, func(out Out) {
+		if !f.finish() {
+			return
+		}
+		if done != nil {
+			done(out)
+		}
+	}))
+}
+
+// FlowStore persists the state of flows run using RunResumable, so that they may be resumed after the submitter
+// disconnected. The state of a flow is stored as the responses submitted for each of its steps so far.
+type FlowStore interface {
+	// Save saves the responses submitted so far for the flow with the ID passed, for the key passed.
+	Save(key, id string, responses [][]byte)
+	// Load loads the responses saved for the flow with the ID passed, for the key passed. If no responses were saved,
+	// false is returned.
+	Load(key, id string) ([][]byte, bool)
+	// Delete deletes the responses saved for the flow with the ID passed, for the key passed.
+	Delete(key, id string)
+}
+
+// MemoryFlowStore is a FlowStore that keeps the state of flows in memory. The zero value of a MemoryFlowStore is ready
+// to use. A MemoryFlowStore is safe for concurrent use.
+type MemoryFlowStore struct {
+	mu sync.Mutex
+	m  map[[2]string][][]byte
+}
+
+// Save ...
+func (s *MemoryFlowStore) Save(key, id string, responses [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[[2]string][][]byte)
+	}
+	s.m[[2]string{key, id}] = responses
+}
+
+// Load ...
+func (s *MemoryFlowStore) Load(key, id string) ([][]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	responses, ok := s.m[[2]string{key, id}]
+	return responses, ok
+}
+
+// Delete ...
+func (s *MemoryFlowStore) Delete(key, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, [2]string{key, id})
+}
+
+// ResumePrompt configures the modal shown by RunResumable to ask a submitter if it wants to resume a flow where it
+// left off. Empty fields are replaced with a default text, so the zero value of a ResumePrompt is ready to use.
+type ResumePrompt struct {
+	// Title is the title of the modal. If empty, "Resume" is used.
+	Title string
+	// Content is the content of the modal. If empty, "Do you want to resume where you left off?" is used.
+	Content string
+	// Resume is the text of the button that resumes the flow where the submitter left off. If empty, "Resume" is
+	// used.
+	Resume string
+	// Restart is the text of the button that starts the flow over from the first step. If empty, "Start over" is
+	// used.
+	Restart string
+	// Close is called if the submitter closes the modal without clicking either button. The flow is then not run,
+	// and the saved responses are kept, so that the submitter is asked again the next time RunResumable is called.
+	// Close may be nil.
+	Close func()
+}
+
+// modal returns the Modal of the prompt, with resume and restart as the Submit of its buttons.
+func (p ResumePrompt) modal(resume, restart func()) *Modal {
+	m := &Modal{
+		Title:   p.Title,
+		Content: p.Content,
+		Button1: Button{Text: p.Resume, Submit: resume},
+		Button2: Button{Text: p.Restart, Submit: restart},
+		Submit: func(closed bool) {
+			if closed && p.Close != nil {
+				p.Close()
+			}
+		},
+	}
+	if m.Title == "" {
+		m.Title = "Resume"
+	}
+	if m.Content == "" {
+		m.Content = "Do you want to resume where you left off?"
+	}
+	if m.Button1.Text == "" {
+		m.Button1.Text = "Resume"
+	}
+	if m.Button2.Text == "" {
+		m.Button2.Text = "Start over"
+	}
+	return m
+}
+
+// RunResumable runs a flow like Run, but saves the responses submitted for every step in the store passed under the
+// key passed, which should identify the submitter across sessions, such as its UUID. If the store holds responses
+// for the flow when RunResumable is called, for example because the submitter disconnected during the flow, the
+// submitter is first asked if it wants to resume where it left off using the prompt passed. If so, the saved
+// responses are submitted to the steps again without showing their forms, so that the submitter continues at the
+// step where it left off. If the submitter chooses to start over, the saved responses are deleted and the flow is run
+// from the first step. If the prompt is closed, the flow is not run and the saved responses are kept. The response
+// of a step is saved once the step produced its result.
+//
+// Because steps are resumed by submitting their responses again, the steps of a resumable flow must be
+// deterministic: Given the same input, a step must return the same form. If a saved response can no longer be
+// submitted, the saved responses are deleted and the form of the step is shown instead. The saved responses are
+// deleted once the flow finishes or is cancelled, but not if it is cancelled using Disconnect.
+func RunResumable[In, Out any](submitter form.Submitter, key, id string, store FlowStore, prompt ResumePrompt,
+	step Step[In, Out], in In, done func(out Out)) {
+	run := func(replay [][]byte) {
+		f := &Flow{Submitter: submitter, ID: id, store: store, key: key, replay: replay}
+		start(f, step, in, func(out Out) {
+			store.Delete(key, id)
+			if done != nil {
+				done(out)
+			}
+		})
+	}
+	responses, ok := store.Load(key, id)
+	if !ok || len(responses) == 0 {
+		run(nil)
+		return
+	}
+	submitter.SendForm(prompt.modal(func() { run(responses) }, func() {
+		store.Delete(key, id)
+		run(nil)
+	}))
+}
+
+// Cancel cancels the flow with the ID passed running for the submitter passed, if any. It returns false if no such
+// flow was running.
+func Cancel(submitter form.Submitter, id string) bool {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return false
+	}
+	flows.Lock()
+	f, ok := flows.m[flowKey{s: submitter, id: id}]
+	flows.Unlock()
+	if ok {
+		f.Cancel()
+	}
+	return ok
+}
+
+// Disconnect cancels all flows running for the submitter passed, such as when it leaves the server, so that they are
+// no longer held. Unlike Cancel, the responses saved for flows run using RunResumable are kept, so that they may be
+// resumed once the submitter joins again.
+func Disconnect(submitter form.Submitter) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return
+	}
+	var running []*Flow
+	flows.Lock()
+	for k, f := range flows.m {
+		if k.s == submitter {
+			running = append(running, f)
+		}
+	}
+	flows.Unlock()
+	for _, f := range running {
+		f.cancel(false)
+	}
+}
+
+// Step returns the index of the step of the flow currently shown to the submitter, starting at 0.
+func (f *Flow) Step() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.step
+}
+
+// Defer registers a function that is run if the flow is cancelled, such as a function that releases a reservation
+// made in one of the steps. Functions are run in the reverse order of registration. They are not run if the flow
+// finishes normally. To notify the submitter of the cancellation, a function that sends a form may be registered.
+func (f *Flow) Defer(cleanup func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+// Cancel cancels the flow, so that results produced by the step currently shown are ignored, and runs all functions
+// registered using Defer. Cancel does nothing if the flow was already cancelled or finished. Note that the form of the
+// step currently shown is not closed on the client.
+func (f *Flow) Cancel() {
+	f.cancel(true)
+}
+
+// cancel cancels the flow. If erase is true, the responses saved in the FlowStore of the flow, if any, are deleted.
+func (f *Flow) cancel(erase bool) {
+	f.mu.Lock()
+	if f.done {
+		f.mu.Unlock()
+		return
+	}
+	f.done, f.cancelled = true, true
+	cleanups := f.cleanups
+	f.cleanups = nil
+	f.mu.Unlock()
+	f.log(FlowEvent{Type: "cancel"})
+
+	f.remove()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	if erase && f.store != nil {
+		f.store.Delete(f.key, f.ID)
+	}
+}
+
+// Cancelled checks if the flow was cancelled.
+func (f *Flow) Cancelled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cancelled
+}
+
+// next moves the flow on to the next step, saving the response submitted for the previous step. It returns false if
+// the flow was already cancelled.
+func (f *Flow) next() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.done {
+		return false
+	}
+	f.step++
+	if f.store != nil && f.pending != nil {
+		f.responses = append(f.responses, f.pending)
+		f.store.Save(f.key, f.ID, append([][]byte(nil), f.responses...))
+	}
+	f.pending = nil
+	return true
+}
+
+// finish marks the flow as finished. It returns false if the flow was already cancelled.
+func (f *Flow) finish() bool {
+	f.mu.Lock()
+	if f.done {
+		f.mu.Unlock()
+		return false
+	}
+	f.done, f.cleanups = true, nil
+	f.mu.Unlock()
+	f.log(FlowEvent{Type: "finish"})
+
+	f.remove()
+	return true
+}
+
+// send sends the form of a step of the flow to its submitter. If the flow is being resumed, the form is submitted
+// with the next response to replay instead. If submitting the response fails, the responses saved are deleted and the
+// form is sent after all.
+func (f *Flow) send(step form.Form) {
+	ff := flowForm{Form: step, f: f}
+	f.mu.Lock()
+	if len(f.replay) == 0 {
+		f.mu.Unlock()
+		f.log(FlowEvent{Type: "send", Form: fmt.Sprintf("%T", step)})
+		f.Submitter.SendForm(ff)
+		return
+	}
+	data := f.replay[0]
+	f.replay = f.replay[1:]
+	f.mu.Unlock()
+	f.log(FlowEvent{Type: "replay", Form: fmt.Sprintf("%T", step), Data: data})
+	if err := ff.SubmitJSON(data, f.Submitter); err != nil && !f.Cancelled() {
+		f.mu.Lock()
+		f.replay = nil
+		f.mu.Unlock()
+		f.store.Delete(f.key, f.ID)
+		f.log(FlowEvent{Type: "send", Form: fmt.Sprintf("%T", step)})
+		f.Submitter.SendForm(ff)
+	}
+}
+
+// submitted sets the response passed as the response submitted for the step currently shown. It is saved in the
+// FlowStore of the flow, if it has one, once the flow moves on to the next step. If nil is passed, the response is
+// discarded.
+func (f *Flow) submitted(data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = append([]byte(nil), data...)
+}
+
+// Events returns a copy of the event log of the flow, oldest event first.
+func (f *Flow) Events() []FlowEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FlowEvent(nil), f.events...)
+}
+
+// MarshalJSON encodes the flow, including its event log, as JSON, so that it may be dumped for debugging.
+func (f *Flow) MarshalJSON() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return json.Marshal(map[string]any{
+		"id":        f.ID,
+		"step":      f.step,
+		"done":      f.done,
+		"cancelled": f.cancelled,
+		"events":    f.events,
+	})
+}
+
+// log adds the event passed to the event log of the flow.
+func (f *Flow) log(e FlowEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e.Time, e.Step = time.Now(), f.step
+	if e.Data != nil && !json.Valid(e.Data) {
+		// Responses are submitted by the client and may not be valid JSON, which would otherwise break the JSON dump.
+		e.Data, _ = json.Marshal(string(e.Data))
+	}
+	if len(f.events) == MaxFlowEvents {
+		f.events = append(f.events[:0:0], f.events[1:]...)
+	}
+	f.events = append(f.events, e)
+}
+
+// remove removes the flow from the flows map.
+func (f *Flow) remove() {
+	if !reflect.TypeOf(f.Submitter).Comparable() {
+		return
+	}
+	flows.Lock()
+	defer flows.Unlock()
+	if k := (flowKey{s: f.Submitter, id: f.ID}); flows.m[k] == f {
+		delete(flows.m, k)
+	}
+}
+
+// flowForm is the form of a step of a Flow. It cancels the flow when closed.
+type flowForm struct {
+	form.Form
+	f *Flow
+}
+
+// SubmitJSON ...
+func (f flowForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if f.f.Cancelled() {
+		// The flow was cancelled while the form was open, so the response is no longer relevant.
+		return nil
+	}
+	if data == nil {
+		f.f.log(FlowEvent{Type: "close"})
+		err := f.Form.SubmitJSON(data, submitter)
+		f.f.Cancel()
+		return err
+	}
+	f.f.submitted(data)
+	f.f.log(FlowEvent{Type: "submit", Data: append(json.RawMessage(nil), data...)})
+	err := f.Form.SubmitJSON(data, submitter)
+	if err != nil {
+		f.f.submitted(nil)
+		f.f.log(FlowEvent{Type: "error", Error: err.Error()})
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+)
+
+// gridPageSize is the width and height of the part of a grid displayed on a single page of a GridSelector.
+const gridPageSize = 5
+
+// GridCell describes how a single cell of a GridSelector is displayed.
+type GridCell struct {
+	// Text is the text of the button of the cell. If empty, the coordinates of the cell are displayed.
+	Text string
+	// Image is the path or URL to the image displayed on the button of the cell, such as an icon indicating if the
+	// cell is occupied. It may be empty.
+	Image string
+	// Disabled specifies if the cell may not be selected.
+	Disabled bool
+}
+
+// GridSelector represents a menu used to select a cell of a grid, such as a plot or region on a map, or a square on a
+// battleship board. The grid is displayed in pages of 5x5 cells, with every row of a page under its own header, and
+// buttons to move the page across the grid. The cell clicked is translated into coordinates for Submit.
+type GridSelector struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before the grid.
+	Content string
+	// Width and Height are the amount of columns and rows of the grid.
+	Width, Height int
+	// OriginX and OriginZ are the coordinates of the first cell of the grid.
+	OriginX, OriginZ int
+	// CellSize is the distance in coordinates between two neighbouring cells, such as the size of a plot. If zero,
+	// cells are 1 apart.
+	CellSize int
+	// Cell returns how the cell at the coordinates passed is displayed, such as whether it is occupied. Cell may be
+	// nil, in which case every cell is displayed with its coordinates.
+	Cell func(x, z int) GridCell
+	// Submit is called with the coordinates of the cell clicked by the player.
+	Submit func(submitter form.Submitter, x, z int)
+}
+
+// Send sends the page of the grid holding its first cell to the submitter passed.
+func (g GridSelector) Send(submitter form.Submitter) {
+	submitter.SendForm(g.page(submitter, 0, 0))
+}
+
+// coordinates returns the coordinates of the cell in the column and row passed.
+func (g GridSelector) coordinates(col, row int) (x, z int) {
+	size := g.CellSize
+	if size <= 0 {
+		size = 1
+	}
+	return g.OriginX + col*size, g.OriginZ + row*size
+}
+
+// page returns the menu displaying the page of the grid starting at the column and row passed.
+func (g GridSelector) page(submitter form.Submitter, col, row int) *Menu {
+	m := NewMenu(g.Title, g.Content)
+	if pages := g.pages(); pages > 1 {
+		m.Content = fmt.Sprintf("%v\n\nPage %v/%v", g.Content, (row/gridPageSize)*g.columns()+col/gridPageSize+1, pages)
+	}
+	for r := row; r < row+gridPageSize && r < g.Height; r++ {
+		_, z := g.coordinates(col, r)
+		m.Elements = append(m.Elements, Header{Text: fmt.Sprintf("Row %v", z)})
+		for c := col; c < col+gridPageSize && c < g.Width; c++ {
+			x, z := g.coordinates(c, r)
+			cell := GridCell{}
+			if g.Cell != nil {
+				cell = g.Cell(x, z)
+			}
+			if cell.Text == "" {
+				cell.Text = fmt.Sprintf("%v, %v", x, z)
+			}
+			m.Elements = append(m.Elements, Button{Text: cell.Text, Image: cell.Image, Disabled: cell.Disabled,
+				Unavailable: func() {
+					submitter.SendForm(g.page(submitter, col, row))
+				},
+				Submit: func() {
+					if g.Submit != nil {
+						g.Submit(submitter, x, z)
+					}
+				},
+			})
+		}
+	}
+	move := func(text string, dc, dr int) {
+		c, r := col+dc*gridPageSize, row+dr*gridPageSize
+		if c < 0 || r < 0 || c >= g.Width || r >= g.Height {
+			return
+		}
+		m.AddButtons(Button{Text: text, Submit: func() {
+			submitter.SendForm(g.page(submitter, c, r))
+		}})
+	}
+	move("Up", 0, -1)
+	move("Down", 0, 1)
+	move("Left", -1, 0)
+	move("Right", 1, 0)
+	return m
+}
+
+// columns returns the amount of pages needed to display all columns of the grid.
+func (g GridSelector) columns() int {
+	return (g.Width + gridPageSize - 1) / gridPageSize
+}
+
+// pages returns the total amount of pages of the grid.
+func (g GridSelector) pages() int {
+	return g.columns() * ((g.Height + gridPageSize - 1) / gridPageSize)
+}
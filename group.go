@@ -0,0 +1,148 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+)
+
+// ErrClosed is the error of a GroupResult of a submitter that closed its form.
+var ErrClosed = errors.New("form was closed")
+
+// GroupResult is the result of a single submitter of a Group.
+type GroupResult[T any] struct {
+	// Value is the value resolved by the form of the submitter.
+	Value T
+	// Err is ErrClosed if the submitter closed its form, the error returned when submitting the form, or the error of
+	// the context if the submitter did not respond in time. If Err is not nil, Value is the zero value.
+	Err error
+}
+
+// Group sends a prompt to every submitter passed and waits until all of them have responded or the context passed is
+// done, such as to ask all members of a party to confirm. The prompt is built separately for every submitter using
+// the function passed, and calls resolve with the value of the submitter once submitted. A prompt that is submitted
+// without calling resolve results in the zero value. Group returns the result of every submitter. Submitters of which
+// the type is not comparable are left out, as they cannot be used as keys of the map returned.
+//
+// Group blocks until all submitters responded, so it must be called on a goroutine of its own: Forms are submitted on
+// the goroutines of the players, such as in a form's Submit function, and calling Group from there would block the
+// responses it waits for until the context is done. GroupAsync may be used instead to run Group in the background.
+//
+//	results := form.Group(ctx, members, func(s form.Submitter, resolve func(bool)) form.Form {
+//		return form.NewModal("Party", "Start the match?", form.Button{Text: "Yes", Submit: func() {
+//			resolve(true)
+//		}}, form.Button{Text: "No"})
+//	})
+func Group[T any](ctx context.Context, submitters []form.Submitter, prompt func(submitter form.Submitter,
+	resolve func(value T)) form.Form) map[form.Submitter]GroupResult[T] {
+	g := &group[T]{results: make(map[form.Submitter]GroupResult[T]), done: make(chan struct{})}
+	var unique []form.Submitter
+	for _, s := range submitters {
+		if reflect.TypeOf(s).Comparable() {
+			if _, ok := g.results[s]; !ok {
+				unique = append(unique, s)
+				g.results[s] = GroupResult[T]{}
+			}
+		}
+	}
+	g.pending = len(unique)
+	if g.pending == 0 {
+		return g.results
+	}
+	for _, s := range unique {
+		s := s
+		var value T
+		f := prompt(s, func(v T) {
+			value = v
+		})
+		s.SendForm(groupForm[T]{Form: f, submit: func(err error) {
+			g.resolve(s, value, err)
+		}})
+	}
+
+	select {
+	case <-g.done:
+	case <-ctx.Done():
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.finished = true
+	for s := range g.results {
+		if !g.responded(s) {
+			g.results[s] = GroupResult[T]{Err: ctx.Err()}
+		}
+	}
+	results := make(map[form.Submitter]GroupResult[T], len(g.results))
+	for s, r := range g.results {
+		results[s] = r
+	}
+	return results
+}
+
+// GroupAsync runs Group in the background and calls done with the result of every submitter once all of them have
+// responded or the context passed is done. Unlike Group, GroupAsync may be called from a form's Submit function.
+func GroupAsync[T any](ctx context.Context, submitters []form.Submitter, prompt func(submitter form.Submitter,
+	resolve func(value T)) form.Form, done func(results map[form.Submitter]GroupResult[T])) {
+	go func() {
+		done(Group(ctx, submitters, prompt))
+	}()
+}
+
+// group holds the state of a call to Group.
+type group[T any] struct {
+	mu        sync.Mutex
+	results   map[form.Submitter]GroupResult[T]
+	responses map[form.Submitter]bool
+	pending   int
+	finished  bool
+	done      chan struct{}
+}
+
+// resolve stores the result of the submitter passed. Results of submitters that already responded, or results stored
+// after Group returned, are ignored.
+func (g *group[T]) resolve(s form.Submitter, value T, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.finished || g.responded(s) {
+		return
+	}
+	if g.responses == nil {
+		g.responses = make(map[form.Submitter]bool)
+	}
+	g.responses[s] = true
+	if err != nil {
+		g.results[s] = GroupResult[T]{Err: err}
+	} else {
+		g.results[s] = GroupResult[T]{Value: value}
+	}
+	if g.pending--; g.pending == 0 {
+		close(g.done)
+	}
+}
+
+// responded checks if the submitter passed responded to its prompt. It must be called with the mutex locked.
+func (g *group[T]) responded(s form.Submitter) bool {
+	return g.responses[s]
+}
+
+// groupForm is the prompt of a single submitter of a Group.
+type groupForm[T any] struct {
+	form.Form
+	submit func(err error)
+}
+
+// SubmitJSON ...
+func (f groupForm[T]) SubmitJSON(data []byte, submitter form.Submitter) error {
+	err := f.Form.SubmitJSON(data, submitter)
+	switch {
+	case err != nil:
+		f.submit(err)
+	case data == nil:
+		f.submit(ErrClosed)
+	default:
+		f.submit(nil)
+	}
+	return err
+}
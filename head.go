@@ -0,0 +1,138 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HeadImages builds the images of player heads for buttons, so that menus listing players may show their avatars.
+// The URL of the head of every player is resolved once in the background and cached per XUID. The zero value of
+// HeadImages is ready to use, but returns the Fallback for every player until Resolve is set. HeadImages is safe for
+// concurrent use.
+type HeadImages struct {
+	// Resolve resolves the URL of the image of the head of the player with the XUID passed. It is called in the
+	// background at most once per XUID, unless it returns an error. If nil, heads are not resolved and the Fallback is
+	// always returned. Resolve may be set to ResolveGeyserHead to look up heads using the GeyserMC global API, which
+	// sends the XUIDs of players to a third party.
+	Resolve func(xuid string) (string, error)
+	// Fallback is the image returned while the head of a player is being resolved, or if it could not be resolved. If
+	// empty, 'textures/ui/icon_steve' is used.
+	Fallback string
+	// RetryAfter is the time after which the head of a player is resolved again if resolving it failed. If zero, heads
+	// are resolved again after 10 minutes.
+	RetryAfter time.Duration
+
+	mu        sync.Mutex
+	cache     map[string]string
+	failed    map[string]time.Time
+	resolving map[string]struct{}
+}
+
+// Image returns the image of the head of the player with the XUID passed, to be used as Image of a Button. If the
+// URL of the head was not yet resolved, it is resolved in the background and the Fallback is returned, so that the
+// head is displayed the next time a menu is sent.
+func (h *HeadImages) Image(xuid string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if url, ok := h.cache[xuid]; ok {
+		return url
+	}
+	if h.Resolve == nil || !validXUID(xuid) {
+		return h.fallback()
+	}
+	if failed, ok := h.failed[xuid]; ok {
+		if time.Since(failed) < h.retryAfter() {
+			return h.fallback()
+		}
+		delete(h.failed, xuid)
+	}
+	if _, ok := h.resolving[xuid]; !ok {
+		if h.resolving == nil {
+			h.resolving = make(map[string]struct{})
+		}
+		h.resolving[xuid] = struct{}{}
+		go h.resolve(xuid)
+	}
+	return h.fallback()
+}
+
+// resolve resolves the URL of the head of the player with the XUID passed and caches it.
+func (h *HeadImages) resolve(xuid string) {
+	url, err := h.Resolve(xuid)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.resolving, xuid)
+	if err != nil || ValidateImage(url) != nil {
+		if h.failed == nil {
+			h.failed = make(map[string]time.Time)
+		}
+		h.failed[xuid] = time.Now()
+		return
+	}
+	if h.cache == nil {
+		h.cache = make(map[string]string)
+	}
+	h.cache[xuid] = url
+}
+
+// fallback returns the image returned if the head of a player was not resolved.
+func (h *HeadImages) fallback() string {
+	if h.Fallback == "" {
+		return "textures/ui/icon_steve"
+	}
+	return h.Fallback
+}
+
+// retryAfter returns the time after which the head of a player is resolved again if resolving it failed.
+func (h *HeadImages) retryAfter() time.Duration {
+	if h.RetryAfter == 0 {
+		return time.Minute * 10
+	}
+	return h.RetryAfter
+}
+
+// validXUID checks if the XUID passed is made up of digits only.
+func validXUID(xuid string) bool {
+	if xuid == "" {
+		return false
+	}
+	for _, r := range xuid {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// headClient is the HTTP client used by ResolveGeyserHead.
+var headClient = &http.Client{Timeout: 10 * time.Second}
+
+// ResolveGeyserHead resolves the URL of the head of the player with the XUID passed by looking up the texture ID of
+// its skin using the GeyserMC global API, and rendering it using mc-heads.net. An error is returned if the XUID is not
+// made up of digits only.
+func ResolveGeyserHead(xuid string) (string, error) {
+	if !validXUID(xuid) {
+		return "", fmt.Errorf("invalid xuid %q", xuid)
+	}
+	resp, err := headClient.Get("https://api.geysermc.org/v2/skin/" + xuid)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status looking up skin of %v: %v", xuid, resp.Status)
+	}
+	var skin struct {
+		TextureID string `json:"texture_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&skin); err != nil {
+		return "", fmt.Errorf("error decoding skin of %v: %w", xuid, err)
+	} else if skin.TextureID == "" {
+		return "", fmt.Errorf("no skin found for %v", xuid)
+	}
+	return "https://mc-heads.net/avatar/" + skin.TextureID, nil
+}
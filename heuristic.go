@@ -0,0 +1,134 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Response is a response submitted by a player for a form, as inspected by a Heuristic.
+type Response struct {
+	// ID is the ID passed to Inspector.Inspect for the form.
+	ID string
+	// Submitter is the Submitter that submitted the response.
+	Submitter form.Submitter
+	// Data is the raw JSON data submitted.
+	Data []byte
+	// Elapsed is the time between the form being sent and the response being submitted.
+	Elapsed time.Duration
+}
+
+// Heuristic scores how suspicious a Response is. It returns a score between 0 and 1, where 0 means the response is
+// not suspicious at all and 1 means the response cannot have been submitted by a human, together with a reason that
+// explains the score.
+type Heuristic func(r Response) (score float64, reason string)
+
+// Inspector runs heuristics against the responses of players to forms, to detect responses that were likely not
+// submitted by a human. Suspicious responses are reported rather than rejected, so that an anti-cheat may decide
+// what to do with them.
+type Inspector struct {
+	// Heuristics holds the heuristics run against every response.
+	Heuristics []Heuristic
+	// Threshold is the minimum score a heuristic must return for a response to be reported.
+	Threshold float64
+	// Report is called for every heuristic that scores a response at or above the Threshold. Report is called before
+	// the response is submitted to the form.
+	Report func(r Response, score float64, reason string)
+}
+
+// Inspect wraps a form so that responses submitted for it are inspected. Responses of closed forms are not inspected.
+// A form returned by Inspect should only be sent once: Inspect should be called for every time the form is sent.
+func (i *Inspector) Inspect(id string, f form.Form) form.Form {
+	return &trackedForm{Form: f, observe: func(data []byte, submitter form.Submitter, elapsed time.Duration) {
+		if i.Report == nil {
+			return
+		}
+		r := Response{ID: id, Submitter: submitter, Data: data, Elapsed: elapsed}
+		for _, h := range i.Heuristics {
+			if score, reason := h(r); score > 0 && score >= i.Threshold {
+				i.Report(r, score, reason)
+			}
+		}
+	}}
+}
+
+// FastSubmit returns a Heuristic that flags responses to forms with at least minValues values that were submitted
+// faster than min. Responses to menu and modal forms have a single value.
+func FastSubmit(min time.Duration, minValues int) Heuristic {
+	return func(r Response) (float64, string) {
+		if r.Elapsed >= min || responseValues(r.Data) < minValues {
+			return 0, ""
+		}
+		return 1, fmt.Sprintf("%v values submitted in %v", responseValues(r.Data), r.Elapsed)
+	}
+}
+
+// RepeatedResponses returns a Heuristic that flags a submitter after submitting exactly the same response to a form
+// n times in a row. The score increases with every repeated response after that. The responses of a submitter to a
+// form are forgotten once it has not responded to the form for 10 minutes, so that submitters that left the server
+// are not held forever.
+func RepeatedResponses(n int) Heuristic {
+	type key struct {
+		s  form.Submitter
+		id string
+	}
+	type history struct {
+		last  []byte
+		count int
+		at    time.Time
+	}
+	var (
+		mu        sync.Mutex
+		histories = make(map[key]*history)
+		swept     = time.Now()
+	)
+	return func(r Response) (float64, string) {
+		if r.Submitter == nil || !reflect.TypeOf(r.Submitter).Comparable() || responseValues(r.Data) < 2 {
+			return 0, ""
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if now.Sub(swept) >= repeatedResponsesTTL {
+			for k, h := range histories {
+				if now.Sub(h.at) >= repeatedResponsesTTL {
+					delete(histories, k)
+				}
+			}
+			swept = now
+		}
+		k := key{s: r.Submitter, id: r.ID}
+		h, ok := histories[k]
+		if !ok || now.Sub(h.at) >= repeatedResponsesTTL {
+			h = &history{}
+			histories[k] = h
+		}
+		h.at = now
+		if bytes.Equal(h.last, r.Data) {
+			h.count++
+		} else {
+			h.last, h.count = append([]byte(nil), r.Data...), 1
+		}
+		if h.count < n {
+			return 0, ""
+		}
+		return 1 - 1/float64(h.count-n+2), fmt.Sprintf("identical response submitted %v times in a row", h.count)
+	}
+}
+
+// repeatedResponsesTTL is the time after which the responses of a submitter to a form are forgotten by
+// RepeatedResponses.
+const repeatedResponsesTTL = time.Minute * 10
+
+// responseValues returns the amount of values in the response data passed.
+func responseValues(data []byte) int {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return 1
+	}
+	return len(values)
+}
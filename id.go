@@ -0,0 +1,202 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Identified is an element that was assigned an ID using WithID. The ID may be used to look up or replace the element
+// in a form, and to reference the value submitted for it by name rather than by its index, which allows forms to be
+// built from multiple modules.
+type Identified struct {
+	// ID is the ID of the element.
+	ID string
+	// element is the element that was assigned the ID.
+	element json.Marshaler
+}
+
+// WithID assigns the ID passed to an element of a Custom form. The element returned may be added to the form in place
+// of the element passed.
+func WithID(id string, e Element) Identified {
+	return Identified{ID: id, element: e}
+}
+
+// MenuWithID assigns the ID passed to an element of a Menu. The element returned may be added to the Elements of the
+// menu in place of the element passed.
+func MenuWithID(id string, e MenuElement) Identified {
+	return Identified{ID: id, element: e}
+}
+
+// Element returns the element that was assigned the ID.
+func (i Identified) Element() json.Marshaler {
+	return i.element
+}
+
+// MarshalJSON ...
+func (i Identified) MarshalJSON() ([]byte, error) {
+	if i.element == nil {
+		return nil, fmt.Errorf("element with id %q is nil", i.ID)
+	}
+	return i.element.MarshalJSON()
+}
+
+// SubmitValue ...
+func (i Identified) SubmitValue(value any) error {
+	if e, ok := i.element.(Element); ok {
+		return e.SubmitValue(value)
+	}
+	return nil
+}
+
+// Clickable ...
+func (i Identified) Clickable() bool {
+	e, ok := i.element.(MenuElement)
+	return ok && e.Clickable()
+}
+
+// Click ...
+func (i Identified) Click() {
+	if e, ok := i.element.(MenuElement); ok {
+		e.Click()
+	}
+}
+
+// unwrap returns the element that was assigned an ID if the element passed is Identified, or the element itself if
+// not.
+func unwrap[T any](element T) T {
+	if i, ok := any(element).(Identified); ok {
+		if e, ok := i.element.(T); ok {
+			return e
+		}
+	}
+	return element
+}
+
+// idOf returns the ID of the element passed, or an empty string if it was not assigned one.
+func idOf(element any) string {
+	if i, ok := element.(Identified); ok {
+		return i.ID
+	}
+	return ""
+}
+
+// ElementByID returns the element of the form with the ID passed, as it was passed to WithID. If no element of the
+// form has the ID, false is returned.
+func (form *Custom) ElementByID(id string) (Element, bool) {
+	if i := form.indexOf(id); i != -1 {
+		return unwrap(form.Elements[i]), true
+	}
+	return nil, false
+}
+
+// ReplaceElement replaces the element of the form with the ID passed with the element passed, which is assigned the
+// same ID. It returns false if no element of the form has the ID.
+func (form *Custom) ReplaceElement(id string, e Element) bool {
+	i := form.indexOf(id)
+	if i == -1 {
+		return false
+	}
+	form.Elements[i] = WithID(id, e)
+	return true
+}
+
+// Named returns the values passed to the Submit of the form by the IDs of their elements. Values of elements without
+// an ID are left out.
+func (form *Custom) Named(values []any) map[string]any {
+	m := make(map[string]any)
+	for i, element := range form.Elements {
+		if i >= len(values) {
+			break
+		}
+		if id := idOf(element); id != "" {
+			m[id] = values[i]
+		}
+	}
+	return m
+}
+
+// InsertElement inserts the element passed into the Elements of the form at the index passed, moving the element at
+// that index and all elements after it down. If the index is out of range, the element is appended to the form.
+func (form *Custom) InsertElement(i int, e Element) {
+	form.Elements = insert(form.Elements, i, e)
+}
+
+// RemoveElement removes the element at the index passed from the Elements of the form. It returns false if the index
+// is out of range.
+func (form *Custom) RemoveElement(i int) bool {
+	var ok bool
+	form.Elements, ok = remove(form.Elements, i)
+	return ok
+}
+
+// indexOf returns the index of the element of the form with the ID passed, or -1 if no element has the ID.
+func (form *Custom) indexOf(id string) int {
+	return indexOf(form.Elements, id)
+}
+
+// ElementByID returns the element in the Elements of the form with the ID passed, as it was passed to MenuWithID. If
+// no element has the ID, false is returned.
+func (form *Menu) ElementByID(id string) (MenuElement, bool) {
+	if i := indexOf(form.Elements, id); i != -1 {
+		return unwrap(form.Elements[i]), true
+	}
+	return nil, false
+}
+
+// ReplaceElement replaces the element in the Elements of the form with the ID passed with the element passed, which is
+// assigned the same ID. It returns false if no element has the ID.
+func (form *Menu) ReplaceElement(id string, e MenuElement) bool {
+	i := indexOf(form.Elements, id)
+	if i == -1 {
+		return false
+	}
+	form.Elements[i] = MenuWithID(id, e)
+	return true
+}
+
+// InsertElement inserts the element passed into the Elements of the form at the index passed, moving the element at
+// that index and all elements after it down. If the index is out of range, the element is appended to the Elements.
+// Because the Elements are displayed before the Buttons, the index of every clickable element after it, as well as
+// of all Buttons, changes if a clickable element is inserted.
+func (form *Menu) InsertElement(i int, e MenuElement) {
+	form.Elements = insert(form.Elements, i, e)
+}
+
+// RemoveElement removes the element at the index passed from the Elements of the form. It returns false if the index
+// is out of range.
+func (form *Menu) RemoveElement(i int) bool {
+	var ok bool
+	form.Elements, ok = remove(form.Elements, i)
+	return ok
+}
+
+// indexOf returns the index of the element with the ID passed in the elements passed, or -1 if no element has the ID.
+func indexOf[T any](elements []T, id string) int {
+	for i, element := range elements {
+		if id != "" && idOf(element) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert inserts the element passed into the elements passed at the index passed. If the index is out of range, the
+// element is appended.
+func insert[T any](elements []T, i int, e T) []T {
+	if i < 0 || i >= len(elements) {
+		return append(elements, e)
+	}
+	elements = append(elements, e)
+	copy(elements[i+1:], elements[i:])
+	elements[i] = e
+	return elements
+}
+
+// remove removes the element at the index passed from the elements passed. It returns false if the index is out of
+// range.
+func remove[T any](elements []T, i int) ([]T, bool) {
+	if i < 0 || i >= len(elements) {
+		return elements, false
+	}
+	return append(elements[:i], elements[i+1:]...), true
+}
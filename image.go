@@ -0,0 +1,64 @@
+package form
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ImageValidator validates the images of buttons. Every image is validated using ValidateImage when a form is
+// encoded, which fails with an error if the image is malformed. An ImageValidator with AllowedHosts may additionally
+// be used to check images that come from configuration or players before they are put in a form.
+type ImageValidator struct {
+	// AllowedHosts holds the hosts that URL images may point to, such as 'i.imgur.com'. If empty, URL images may point
+	// to any host.
+	AllowedHosts []string
+}
+
+// ValidateImage checks if the image passed is a valid image for a Button using an ImageValidator without
+// AllowedHosts, so that URL images may point to any host.
+func ValidateImage(image string) error {
+	return ImageValidator{}.Validate(image)
+}
+
+// Validate checks if the image passed is a valid image for a Button. URL images must use the http or https scheme,
+// have a host and point to one of the AllowedHosts if set. Local asset paths must be relative paths within the
+// 'textures/' directory, such as 'textures/blocks/grass_carried'. An empty image is valid.
+func (v ImageValidator) Validate(image string) error {
+	if image == "" {
+		return nil
+	}
+	if strings.ContainsAny(image, " \t\n\\") {
+		return fmt.Errorf("image %q contains whitespace or backslashes", image)
+	}
+	if strings.HasPrefix(image, "http:") || strings.HasPrefix(image, "https:") {
+		u, err := url.Parse(image)
+		if err != nil {
+			return fmt.Errorf("image %q is not a valid URL: %w", image, err)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("image URL %q has no host", image)
+		}
+		if len(v.AllowedHosts) == 0 {
+			return nil
+		}
+		for _, host := range v.AllowedHosts {
+			if strings.EqualFold(u.Hostname(), host) {
+				return nil
+			}
+		}
+		return fmt.Errorf("image URL %q points to host %v, which is not allowed", image, u.Hostname())
+	}
+	if i := strings.Index(image, "://"); i != -1 {
+		return fmt.Errorf("image %q has unsupported scheme %v", image, image[:i])
+	}
+	if !strings.HasPrefix(image, "textures/") {
+		return fmt.Errorf("image path %q is not in the textures/ directory", image)
+	}
+	for _, part := range strings.Split(image, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("image path %q is malformed", image)
+		}
+	}
+	return nil
+}
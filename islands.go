@@ -0,0 +1,275 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strings"
+)
+
+// Island is a plot or island of a player managed using Islands.
+type Island struct {
+	// ID is the ID of the island.
+	ID string
+	// Name is the name of the island. It may contain Minecraft formatting codes.
+	Name string
+	// Owner is the name of the owner of the island.
+	Owner string
+	// Members holds the names of the members of the island, excluding its owner.
+	Members []string
+	// Settings holds the settings of the island.
+	Settings IslandSettings
+}
+
+// IslandSettings holds the settings of an Island that may be changed by its owner.
+type IslandSettings struct {
+	// Biome is the biome of the island, which is one of the Biomes of Islands.
+	Biome string
+	// PvP specifies if players may fight each other on the island.
+	PvP bool
+	// Open specifies if the island may be visited by players that are not a member of it.
+	Open bool
+	// VisitorsBuild specifies if visitors may place and break blocks on the island.
+	VisitorsBuild bool
+	// VisitorsContainers specifies if visitors may open containers on the island.
+	VisitorsContainers bool
+}
+
+// IslandProvider provides and changes the islands managed using Islands. Methods returning an error display the
+// error to the player.
+type IslandProvider interface {
+	// Island returns the island of the player passed. If the player has no island, false is returned.
+	Island(submitter form.Submitter) (Island, bool)
+	// Visitable returns the islands that the player passed may visit.
+	Visitable(submitter form.Submitter) []Island
+	// Create creates a new island for the player passed.
+	Create(submitter form.Submitter) error
+	// Home teleports the player passed to its island.
+	Home(submitter form.Submitter) error
+	// Visit teleports the player passed to the island passed.
+	Visit(submitter form.Submitter, island Island) error
+	// Player returns the submitter of the online player with the name passed, so that it may be invited. If no such
+	// player is online, false is returned.
+	Player(name string) (form.Submitter, bool)
+	// AddMember adds the player with the name passed as a member of the island with the ID passed, after the player
+	// accepted the invitation to it.
+	AddMember(id, name string) error
+	// RemoveMember removes the member with the name passed from the island with the ID passed.
+	RemoveMember(id, name string) error
+	// SaveSettings saves the settings passed for the island with the ID passed.
+	SaveSettings(id string, settings IslandSettings) error
+	// Delete deletes the island with the ID passed.
+	Delete(id string) error
+}
+
+// Islands represents the menus used to manage the island of a player on a skyblock or plot server: Teleporting home,
+// visiting other islands, managing and inviting members, changing the settings of the island and deleting it.
+type Islands struct {
+	// Title is the title of the forms.
+	Title string
+	// Provider provides and changes the islands.
+	Provider IslandProvider
+	// Biomes holds the biomes that may be selected in the settings of an island.
+	Biomes []string
+}
+
+// Send sends the main island menu to the submitter passed.
+func (is Islands) Send(submitter form.Submitter) {
+	submitter.SendForm(is.menu(submitter, ""))
+}
+
+// menu returns the main island menu, with the error passed displayed in the content.
+func (is Islands) menu(submitter form.Submitter, err string) *Menu {
+	island, ok := is.Provider.Island(submitter)
+	m := NewMenu(is.Title, "")
+	if !ok {
+		m.Content = "You do not have an island yet."
+		m.AddButtons(Button{Text: "Create island", Submit: func() {
+			is.do(submitter, is.Provider.Create(submitter))
+		}})
+	} else {
+		m.Content = fmt.Sprintf("Island: %v§r\nOwner: %v\nMembers: %v", island.Name, island.Owner, len(island.Members))
+		m.AddButtons(Button{Text: "Teleport home", Submit: func() {
+			if err := is.Provider.Home(submitter); err != nil {
+				submitter.SendForm(is.menu(submitter, err.Error()))
+			}
+		}})
+	}
+	m.AddButtons(Button{Text: "Visit islands", Submit: func() {
+		submitter.SendForm(is.visit(submitter))
+	}})
+	if ok {
+		m.AddButtons(
+			Button{Text: "Members", Submit: func() {
+				submitter.SendForm(is.members(submitter, island, ""))
+			}},
+			Button{Text: "Settings", Submit: func() {
+				submitter.SendForm(is.settings(submitter, island))
+			}},
+			Button{Text: "§cDelete island", Submit: func() {
+				submitter.SendForm(is.delete(submitter, island))
+			}},
+		)
+	}
+	if err != "" {
+		m.Content = strings.TrimSpace(m.Content + "\n\n§c" + err)
+	}
+	return m
+}
+
+// do sends the main island menu to the submitter passed with the error passed, if it is not nil.
+func (is Islands) do(submitter form.Submitter, err error) {
+	if err != nil {
+		submitter.SendForm(is.menu(submitter, err.Error()))
+	}
+}
+
+// visit returns the menu listing the islands that the submitter passed may visit.
+func (is Islands) visit(submitter form.Submitter) *Menu {
+	m := NewMenu(is.Title, "Select an island to visit.")
+	for _, island := range is.Provider.Visitable(submitter) {
+		island := island
+		m.AddButtons(Button{Text: fmt.Sprintf("%v\n§8by %v", island.Name, island.Owner), Submit: func() {
+			is.do(submitter, is.Provider.Visit(submitter, island))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		is.Send(submitter)
+	}})
+	return m
+}
+
+// members returns the menu listing the members of the island passed, with the error passed displayed in the content.
+func (is Islands) members(submitter form.Submitter, island Island, err string) *Menu {
+	content := fmt.Sprintf("Owner: %v\nClick a member to remove them from the island.", island.Owner)
+	if err != "" {
+		content += "\n\n§c" + err
+	}
+	m := NewMenu(is.Title, content)
+	for _, member := range island.Members {
+		member := member
+		m.AddButtons(Button{Text: member, Submit: func() {
+			submitter.SendForm(&Modal{
+				Title:   is.Title,
+				Content: fmt.Sprintf("Remove %v from the island?", member),
+				Button1: Button{Text: "Remove", Submit: func() {
+					var msg string
+					if err := is.Provider.RemoveMember(island.ID, member); err != nil {
+						msg = err.Error()
+					}
+					island, _ := is.Provider.Island(submitter)
+					submitter.SendForm(is.members(submitter, island, msg))
+				}},
+				Button2: Button{Text: "Cancel", Submit: func() {
+					submitter.SendForm(is.members(submitter, island, ""))
+				}},
+			})
+		}})
+	}
+	m.AddButtons(
+		Button{Text: "Invite player", Submit: func() {
+			submitter.SendForm(is.invite(submitter, island))
+		}},
+		Button{Text: "Back", Submit: func() {
+			is.Send(submitter)
+		}},
+	)
+	return m
+}
+
+// invite returns the Custom form used to invite a player to the island passed. The player invited is sent a modal to
+// accept or decline the invitation.
+func (is Islands) invite(submitter form.Submitter, island Island) *Custom {
+	var name string
+	return &Custom{
+		Title: is.Title,
+		Elements: []Element{Input{Text: "Player name", Submit: func(s string) {
+			name = strings.TrimSpace(s)
+		}}},
+		Submit: func(closed bool, _ []any) {
+			if closed {
+				submitter.SendForm(is.members(submitter, island, ""))
+				return
+			}
+			target, ok := is.Provider.Player(name)
+			if !ok {
+				submitter.SendForm(is.members(submitter, island, fmt.Sprintf("Player %v is not online.", name)))
+				return
+			}
+			target.SendForm(&Modal{
+				Title:   is.Title,
+				Content: fmt.Sprintf("%v invited you to join their island %v§r.", island.Owner, island.Name),
+				Button1: Button{Text: "Accept", Submit: func() {
+					if err := is.Provider.AddMember(island.ID, name); err != nil {
+						target.SendForm(is.menu(target, err.Error()))
+					}
+				}},
+				Button2: Button{Text: "Decline"},
+			})
+			submitter.SendForm(is.members(submitter, island, ""))
+		},
+	}
+}
+
+// settings returns the Custom form used to change the settings of the island passed.
+func (is Islands) settings(submitter form.Submitter, island Island) *Custom {
+	settings := island.Settings
+	var elements []Element
+	if len(is.Biomes) > 0 {
+		def := 0
+		for i, biome := range is.Biomes {
+			if biome == settings.Biome {
+				def = i
+			}
+		}
+		elements = append(elements, Dropdown{Text: "Biome", Options: is.Biomes, DefaultIndex: def,
+			Submit: func(_ int, option string) {
+				settings.Biome = option
+			},
+		})
+	}
+	elements = append(elements,
+		Toggle{Text: "PvP", Default: settings.PvP, Submit: func(enabled bool) { settings.PvP = enabled }},
+		Toggle{Text: "Open to visitors", Default: settings.Open, Submit: func(enabled bool) { settings.Open = enabled }},
+		Header{Text: "Visitor permissions"},
+		Toggle{Text: "Build", Default: settings.VisitorsBuild, Submit: func(enabled bool) {
+			settings.VisitorsBuild = enabled
+		}},
+		Toggle{Text: "Open containers", Default: settings.VisitorsContainers, Submit: func(enabled bool) {
+			settings.VisitorsContainers = enabled
+		}},
+	)
+	return &Custom{Title: is.Title, Elements: elements, Submit: func(closed bool, _ []any) {
+		if closed {
+			is.Send(submitter)
+			return
+		}
+		is.do(submitter, is.Provider.SaveSettings(island.ID, settings))
+	}}
+}
+
+// delete returns the Custom form used to delete the island passed. The player must type the name of the island to
+// confirm.
+func (is Islands) delete(submitter form.Submitter, island Island) *Custom {
+	var confirm string
+	return &Custom{
+		Title: is.Title,
+		Elements: []Element{
+			Label{Text: "§cDeleting your island cannot be undone."},
+			Input{Text: fmt.Sprintf("Type '%v' to confirm", text.Clean(island.Name)), Submit: func(s string) {
+				confirm = s
+			}},
+		},
+		Submit: func(closed bool, _ []any) {
+			if closed {
+				is.Send(submitter)
+				return
+			}
+			if strings.TrimSpace(confirm) != text.Clean(island.Name) {
+				submitter.SendForm(is.menu(submitter, "The name typed did not match, the island was not deleted."))
+				return
+			}
+			is.do(submitter, is.Provider.Delete(island.ID))
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+	"strings"
+)
+
+// ItemSelector represents a dropdown used to select one of the items registered in the item registry of dragonfly,
+// such as an item to sell in a shop or to add to a kit. The items are displayed with a readable name and sorted by
+// their identifier.
+type ItemSelector struct {
+	// Text is the text displayed over the dropdown element. The text may contain Minecraft formatting codes.
+	Text string
+	// Filter is called for every item registered and returns if the item may be selected. If nil, all items may be
+	// selected.
+	Filter func(it world.Item) bool
+	// Name returns the name of an item displayed in the dropdown. If nil, ItemName is used.
+	Name func(it world.Item) string
+	// Default is the item selected by default. If nil or not one of the items that may be selected, the first item is
+	// selected.
+	Default world.Item
+	// Submit is called with the item selected whenever the form is submitted. If the form is closed, this method is
+	// not called. This is always called before the Form's Submit.
+	Submit func(it world.Item)
+}
+
+// MarshalJSON ...
+func (s ItemSelector) MarshalJSON() ([]byte, error) {
+	items := s.items()
+	options := make([]string, len(items))
+	var def int
+	for i, it := range items {
+		options[i] = s.name(it)
+		if s.Default != nil && sameItem(it, s.Default) {
+			def = i
+		}
+	}
+	return Dropdown{Text: s.Text, Options: options, DefaultIndex: def}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (s ItemSelector) SubmitValue(value any) error {
+	if s.Submit == nil {
+		return nil
+	}
+	items := s.items()
+	var it world.Item
+	err := Dropdown{Options: make([]string, len(items)), Submit: func(i int, _ string) {
+		it = items[i]
+	}}.SubmitValue(value)
+	if err != nil {
+		return err
+	}
+	s.Submit(it)
+	return nil
+}
+
+// items returns the items that may be selected, sorted by their identifier and metadata value.
+func (s ItemSelector) items() []world.Item {
+	var items []world.Item
+	for _, it := range world.Items() {
+		if s.Filter == nil || s.Filter(it) {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		nameI, metaI := items[i].EncodeItem()
+		nameJ, metaJ := items[j].EncodeItem()
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return metaI < metaJ
+	})
+	return items
+}
+
+// name returns the name of the item passed displayed in the dropdown.
+func (s ItemSelector) name(it world.Item) string {
+	if s.Name != nil {
+		return s.Name(it)
+	}
+	return ItemName(it)
+}
+
+// ItemName returns a readable name for the item passed, based on its identifier. For example, the item
+// 'minecraft:diamond_sword' is named 'Diamond Sword'. If the item has a non-zero metadata value, it is added to the
+// name.
+func ItemName(it world.Item) string {
+	id, meta := it.EncodeItem()
+	if i := strings.IndexByte(id, ':'); i != -1 {
+		id = id[i+1:]
+	}
+	words := strings.Split(id, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	name := strings.Join(words, " ")
+	if meta != 0 {
+		name += fmt.Sprintf(" (%v)", meta)
+	}
+	return name
+}
+
+// sameItem checks if the items passed encode to the same identifier and metadata value.
+func sameItem(a, b world.Item) bool {
+	nameA, metaA := a.EncodeItem()
+	nameB, metaB := b.EncodeItem()
+	return nameA == nameB && metaA == metaB
+}
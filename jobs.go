@@ -0,0 +1,128 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// Job is a job or profession that a player may select using Jobs.
+type Job struct {
+	// ID is the ID of the job.
+	ID string
+	// Name is the name of the job. It may contain Minecraft formatting codes.
+	Name string
+	// Description is the description of the job, displayed when the job is selected. It may contain Minecraft
+	// formatting codes.
+	Description string
+	// Image is the path or URL to the image displayed on the button of the job. It may be empty.
+	Image string
+}
+
+// JobProgress is the progress of a player in a Job.
+type JobProgress struct {
+	// Level is the current level of the player in the job.
+	Level int
+	// XP is the experience of the player towards the next level.
+	XP float64
+	// NextLevel is the experience needed to reach the next level. If zero, the player reached the maximum level.
+	NextLevel float64
+}
+
+// JobsProvider provides the jobs displayed by Jobs and the progress of players in them.
+type JobsProvider interface {
+	// Jobs returns the jobs that may be selected.
+	Jobs() []Job
+	// Current returns the current job of the player passed. If the player has no job, false is returned.
+	Current(submitter form.Submitter) (Job, bool)
+	// Progress returns the progress of the player passed in the job passed.
+	Progress(submitter form.Submitter, job Job) JobProgress
+	// SwitchCost returns the cost for the player passed to switch to the job passed. If zero, switching is free.
+	SwitchCost(submitter form.Submitter, job Job) int
+	// Join makes the player passed join the job passed, taking the switch cost. If it returns an error, such as when
+	// the player cannot afford the switch, the error is displayed to the player.
+	Join(submitter form.Submitter, job Job) error
+}
+
+// Jobs represents the menus used to select a job and view the progress of a player in its jobs. Switching jobs must
+// be confirmed by the player, showing the cost of the switch.
+type Jobs struct {
+	// Title is the title of the forms.
+	Title string
+	// Provider provides the jobs and the progress of players in them.
+	Provider JobsProvider
+}
+
+// Send sends the job selection menu to the submitter passed.
+func (j Jobs) Send(submitter form.Submitter) {
+	submitter.SendForm(j.menu(submitter, ""))
+}
+
+// menu returns the job selection menu, with the error passed displayed in the content.
+func (j Jobs) menu(submitter form.Submitter, err string) *Menu {
+	current, ok := j.Provider.Current(submitter)
+	content := "You do not have a job."
+	if ok {
+		p := j.Provider.Progress(submitter, current)
+		content = fmt.Sprintf("Current job: %v§r (level %v)", current.Name, p.Level)
+	}
+	if err != "" {
+		content += "\n\n§c" + err
+	}
+	m := NewMenu(j.Title, content)
+	m.AddButtons(Button{Text: "Progress", Submit: func() {
+		submitter.SendForm(j.progress(submitter))
+	}})
+	for _, job := range j.Provider.Jobs() {
+		job := job
+		text := job.Name
+		if ok && current.ID == job.ID {
+			text += "\n§aCurrent job"
+		}
+		m.AddButtons(Button{Text: text, Image: job.Image, Submit: func() {
+			j.confirm(submitter, job)
+		}})
+	}
+	return m
+}
+
+// confirm sends the modal used to confirm joining the job passed to the submitter passed.
+func (j Jobs) confirm(submitter form.Submitter, job Job) {
+	if current, ok := j.Provider.Current(submitter); ok && current.ID == job.ID {
+		submitter.SendForm(j.menu(submitter, fmt.Sprintf("You already have the job %v§r.", job.Name)))
+		return
+	}
+	content := strings.TrimSpace(job.Description + "\n\nDo you want to become a " + job.Name + "§r?")
+	if cost := j.Provider.SwitchCost(submitter, job); cost > 0 {
+		content += fmt.Sprintf("\nSwitching jobs costs §6%v§r.", cost)
+	}
+	submitter.SendForm(&Modal{
+		Title:   j.Title,
+		Content: content,
+		Button1: Button{Text: "Join", Submit: func() {
+			if err := j.Provider.Join(submitter, job); err != nil {
+				submitter.SendForm(j.menu(submitter, err.Error()))
+			}
+		}},
+		Button2: Button{Text: "Back", Submit: func() {
+			j.Send(submitter)
+		}},
+	})
+}
+
+// progress returns the menu displaying the progress of the submitter passed in every job.
+func (j Jobs) progress(submitter form.Submitter) *Menu {
+	m := NewMenu(j.Title, "Your progress in every job.")
+	for _, job := range j.Provider.Jobs() {
+		p := j.Provider.Progress(submitter, job)
+		text := fmt.Sprintf("Level %v §8- §7max level", p.Level)
+		if p.NextLevel > 0 {
+			text = fmt.Sprintf("Level %v\n%v %.0f/%.0f XP", p.Level, ProgressBar(p.XP, p.NextLevel, 20), p.XP, p.NextLevel)
+		}
+		m.Elements = append(m.Elements, Header{Text: job.Name}, Label{Text: text})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		j.Send(submitter)
+	}})
+	return m
+}
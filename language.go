@@ -0,0 +1,72 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"golang.org/x/text/language"
+)
+
+// LocaleProvider stores the locale chosen by players, so that forms may be translated into it. Players are
+// identified by a key, such as their UUID.
+type LocaleProvider interface {
+	// Locale returns the locale of the player with the key passed. If the player did not choose a locale, false is
+	// returned.
+	Locale(key string) (language.Tag, bool)
+	// SetLocale sets the locale of the player with the key passed.
+	SetLocale(key string, locale language.Tag)
+}
+
+// Language is a language that may be selected in a LanguagePicker.
+type Language struct {
+	// Tag is the locale of the language.
+	Tag language.Tag
+	// Name is the name of the language in the language itself, such as 'Deutsch' or '日本語'.
+	Name string
+	// Image is the path or URL to the image displayed on the button of the language, such as a flag. It may be empty.
+	Image string
+}
+
+// LanguagePicker represents a menu used to select the language of a player, listing every language by its native
+// name. The language selected is stored in the LocaleProvider.
+type LanguagePicker struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Languages holds the languages that may be selected, in the order that they are displayed.
+	Languages []Language
+	// Locales is the LocaleProvider in which the language selected is stored.
+	Locales LocaleProvider
+	// Selected is called after the player selected a language. It may be nil.
+	Selected func(submitter form.Submitter, locale language.Tag)
+}
+
+// Send sends the picker to the submitter passed, which is the player with the key passed.
+func (p LanguagePicker) Send(submitter form.Submitter, key string) {
+	m := NewMenu(p.Title, p.Content)
+	current, ok := p.Locales.Locale(key)
+	for _, lang := range p.Languages {
+		lang := lang
+		text := lang.Name
+		if ok && lang.Tag == current {
+			text += "\n§aSelected"
+		}
+		m.AddButtons(Button{Text: text, Image: lang.Image, Submit: func() {
+			p.Locales.SetLocale(key, lang.Tag)
+			if p.Selected != nil {
+				p.Selected(submitter, lang.Tag)
+			}
+		}})
+	}
+	submitter.SendForm(m)
+}
+
+// SendFirstJoin sends the picker to the submitter passed if the player with the key passed has not yet chosen a
+// locale, such as when it joins for the first time. It should be called when a player joins. It returns true if the
+// picker was sent.
+func (p LanguagePicker) SendFirstJoin(submitter form.Submitter, key string) bool {
+	if _, ok := p.Locales.Locale(key); ok {
+		return false
+	}
+	p.Send(submitter, key)
+	return true
+}
@@ -0,0 +1,152 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the buckets of a Histogram used by a Latency if it has no buckets set.
+var DefaultLatencyBuckets = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+}
+
+// Latency tracks how long players take between a form being sent to them and them submitting it, for each form ID.
+// Forms that take very long to fill out may be confusing, while forms that are submitted very quickly may be
+// blind-clicked. The zero value of a Latency is ready to use. A Latency is safe for concurrent use.
+type Latency struct {
+	// Buckets holds the upper bounds of the buckets of the histograms tracked, in ascending order. If nil,
+	// DefaultLatencyBuckets is used. Buckets should not be changed after the first form is tracked.
+	Buckets []time.Duration
+
+	mu         sync.Mutex
+	histograms map[string]*Histogram
+}
+
+// Histogram is a histogram of the time players took to submit a form.
+type Histogram struct {
+	// Buckets holds the upper bounds of the buckets of the histogram, in ascending order.
+	Buckets []time.Duration
+	// Counts holds the amount of submissions in each bucket. Counts[i] is the amount of submissions that took longer
+	// than Buckets[i-1], but no longer than Buckets[i]. The last element of Counts holds the amount of submissions
+	// that took longer than the last bucket.
+	Counts []uint64
+	// Count is the total amount of submissions observed.
+	Count uint64
+	// Sum is the total time taken by all submissions observed.
+	Sum time.Duration
+}
+
+// Mean returns the mean time taken by submissions in the histogram, or zero if no submissions were observed.
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// observe adds a submission that took the duration passed to the histogram.
+func (h *Histogram) observe(d time.Duration) {
+	h.Counts[sort.Search(len(h.Buckets), func(i int) bool { return d <= h.Buckets[i] })]++
+	h.Count++
+	h.Sum += d
+}
+
+// Track wraps a form so that the time between it being sent and it being submitted is tracked under the ID passed.
+// The ID may be the ID of a form or of a step within a form. Submissions of closed forms are not tracked. A form
+// returned by Track should only be sent once: Track should be called for every time the form is sent.
+func (l *Latency) Track(id string, f form.Form) form.Form {
+	return &trackedForm{Form: f, observe: func(_ []byte, _ form.Submitter, elapsed time.Duration) {
+		l.observe(id, elapsed)
+	}}
+}
+
+// Histogram returns a copy of the histogram tracked for the ID passed. If no submissions were tracked for the ID, the
+// histogram returned is empty.
+func (l *Latency) Histogram(id string) Histogram {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	h, ok := l.histograms[id]
+	if !ok {
+		buckets := l.buckets()
+		return Histogram{Buckets: buckets, Counts: make([]uint64, len(buckets)+1)}
+	}
+	c := *h
+	c.Counts = append([]uint64(nil), h.Counts...)
+	return c
+}
+
+// IDs returns the IDs of which at least one submission was tracked, sorted alphabetically.
+func (l *Latency) IDs() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]string, 0, len(l.histograms))
+	for id := range l.histograms {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// observe adds a submission that took the duration passed to the histogram of the ID passed.
+func (l *Latency) observe(id string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.histograms == nil {
+		l.histograms = make(map[string]*Histogram)
+	}
+	h, ok := l.histograms[id]
+	if !ok {
+		buckets := l.buckets()
+		h = &Histogram{Buckets: buckets, Counts: make([]uint64, len(buckets)+1)}
+		l.histograms[id] = h
+	}
+	h.observe(d)
+}
+
+// buckets returns the bucket bounds used by the Latency.
+func (l *Latency) buckets() []time.Duration {
+	if l.Buckets == nil {
+		return DefaultLatencyBuckets
+	}
+	return l.Buckets
+}
+
+// trackedForm is a form that records the time at which it was sent, such as a form wrapped by a Latency or an
+// Inspector. The observe function is called with every response submitted for a form that was sent, before it is
+// submitted to the form.
+type trackedForm struct {
+	form.Form
+	observe func(data []byte, submitter form.Submitter, elapsed time.Duration)
+
+	mu   sync.Mutex
+	sent time.Time
+}
+
+// MarshalJSON ...
+func (f *trackedForm) MarshalJSON() ([]byte, error) {
+	f.mu.Lock()
+	f.sent = time.Now()
+	f.mu.Unlock()
+	return f.Form.MarshalJSON()
+}
+
+// SubmitJSON ...
+func (f *trackedForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	f.mu.Lock()
+	sent := f.sent
+	f.mu.Unlock()
+	if data != nil && !sent.IsZero() {
+		f.observe(data, submitter, time.Since(sent))
+	}
+	return f.Form.SubmitJSON(data, submitter)
+}
@@ -0,0 +1,153 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"math"
+	"strings"
+)
+
+// LintWarning is a likely mistake in a form, as found by Lint.
+type LintWarning struct {
+	// Element describes where in the form the mistake was found, such as 'title' or 'element 3'.
+	Element string
+	// Message describes the mistake.
+	Message string
+}
+
+// String ...
+func (w LintWarning) String() string {
+	return w.Element + ": " + w.Message
+}
+
+// Lint checks the form passed for likely mistakes that do not prevent the form from being sent, but do make it look
+// or behave differently than intended, such as empty titles, formatting codes that are not reset, malformed images,
+// dropdowns with duplicate options and sliders of which the default is not on a step. Lint may be called for every
+// form of a server on startup. Forms of types other than *Menu, *Modal and *Custom are not linted.
+func Lint(f form.Form) []LintWarning {
+	var l linter
+	switch f := f.(type) {
+	case *Menu:
+		l.title(f.Title)
+		l.text("content", f.Content)
+		for i, element := range f.elements() {
+			l.menuElement(fmt.Sprintf("element %v", i), element)
+		}
+	case *Modal:
+		l.title(f.Title)
+		l.text("content", f.Content)
+		l.text("button 1", f.Button1.Text)
+		l.text("button 2", f.Button2.Text)
+	case *Custom:
+		l.title(f.Title)
+		for i, element := range f.content() {
+			l.element(fmt.Sprintf("element %v", i), element)
+		}
+	}
+	return l.warnings
+}
+
+// linter collects the LintWarnings found in a form.
+type linter struct {
+	warnings []LintWarning
+}
+
+// warn adds a LintWarning for the element passed.
+func (l *linter) warn(element, format string, a ...any) {
+	l.warnings = append(l.warnings, LintWarning{Element: element, Message: fmt.Sprintf(format, a...)})
+}
+
+// title lints the title of a form.
+func (l *linter) title(title string) {
+	if strings.TrimSpace(title) == "" {
+		l.warn("title", "title is empty")
+	}
+	l.text("title", title)
+}
+
+// text lints a text displayed in a form.
+func (l *linter) text(element, text string) {
+	i := strings.LastIndex(text, "§")
+	if i == -1 || i+len("§") >= len(text) {
+		return
+	}
+	if code := text[i+len("§")]; code != 'r' {
+		l.warn(element, "formatting code §%c is not reset using §r", code)
+	}
+}
+
+// menuElement lints an element of a Menu.
+func (l *linter) menuElement(element string, e MenuElement) {
+	switch e := unwrap(e).(type) {
+	case Button:
+		l.text(element, e.Text)
+		if err := ValidateImage(e.Image); err != nil {
+			l.warn(element, "%v", err)
+		}
+	case Label:
+		l.text(element, e.Text)
+	case Header:
+		l.text(element, e.Text)
+	}
+}
+
+// element lints an element of a Custom form.
+func (l *linter) element(element string, e Element) {
+	switch e := unwrap(e).(type) {
+	case Label:
+		l.text(element, e.Text)
+	case Header:
+		l.text(element, e.Text)
+	case Input:
+		l.text(element, e.Text)
+	case Toggle:
+		l.text(element, e.Text)
+	case Slider:
+		l.text(element, e.Text)
+		if e.Min > e.Max {
+			l.warn(element, "minimum %v is higher than maximum %v", e.Min, e.Max)
+		} else if e.Default < e.Min || e.Default > e.Max {
+			l.warn(element, "default %v is out of range %v-%v", e.Default, e.Min, e.Max)
+		}
+		if e.StepSize > 0 {
+			steps := (e.Default - e.Min) / e.StepSize
+			if math.Abs(steps-math.Round(steps)) > 1e-9 {
+				l.warn(element, "default %v is not on a step of size %v", e.Default, e.StepSize)
+			}
+		}
+	case IntSlider:
+		l.text(element, e.Text)
+		if e.Min > e.Max {
+			l.warn(element, "minimum %v is higher than maximum %v", e.Min, e.Max)
+		} else if e.Default < e.Min || e.Default > e.Max {
+			l.warn(element, "default %v is out of range %v-%v", e.Default, e.Min, e.Max)
+		} else if (e.Default-e.Min)%e.step() != 0 {
+			l.warn(element, "default %v is not on a step of size %v", e.Default, e.step())
+		}
+	case Dropdown:
+		l.text(element, e.Text)
+		l.options(element, e.Options, e.DefaultIndex, e.DefaultOption)
+	case StepSlider:
+		l.text(element, e.Text)
+		l.options(element, e.Options, e.DefaultIndex, e.DefaultOption)
+	}
+}
+
+// options lints the options of a Dropdown or StepSlider.
+func (l *linter) options(element string, options []string, index int, option string) {
+	if _, err := defaultIndex(options, index, option); err != nil {
+		l.warn(element, "%v", err)
+	} else if len(options) == 0 {
+		l.warn(element, "no options")
+	} else if option == "" && (index < 0 || index >= len(options)) {
+		l.warn(element, "default index %v is out of range 0-%v", index, len(options)-1)
+	}
+	seen := make(map[string]int, len(options))
+	for i, option := range options {
+		if j, ok := seen[option]; ok {
+			l.warn(element, "option %v (%q) is a duplicate of option %v", i, option, j)
+			continue
+		}
+		seen[option] = i
+	}
+}
@@ -0,0 +1,115 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// ListEditor represents a form used to edit an ordered list of items, such as the order of items in a kit or the
+// rotation of announcements. The items are shown as buttons in their current order. Clicking an item opens a menu
+// to move it up or down or to remove it. The editor is sent to the player again after every change, until the player
+// presses the done button or closes the form.
+type ListEditor struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Items holds the items currently in the editor, in order. It is updated every time the player changes the list.
+	Items []string
+	// Validate is called with every item the player tries to add. If it returns an error, the item is not added and the
+	// error is displayed to the player. If nil, adding items is not possible.
+	Validate func(item string) error
+	// Submit is called with the final items when the player presses the done button or closes the form.
+	Submit func(closed bool, items []string)
+
+	err string
+}
+
+// MarshalJSON ...
+func (editor *ListEditor) MarshalJSON() ([]byte, error) {
+	return editor.menu(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (editor *ListEditor) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return editor.menu(submitter).SubmitJSON(data, submitter)
+}
+
+// menu returns the Menu that the editor currently consists of. Clicking its buttons updates the editor and sends it
+// to the submitter passed again.
+func (editor *ListEditor) menu(submitter form.Submitter) *Menu {
+	content := editor.Content
+	if editor.err != "" {
+		content = strings.TrimSpace(content + "\n\n§c" + editor.err)
+	}
+	m := &Menu{Title: editor.Title, Content: content, Submit: func(closed bool) {
+		if closed && editor.Submit != nil {
+			editor.Submit(true, editor.Items)
+		}
+	}}
+	for i, item := range editor.Items {
+		i := i
+		m.AddButtons(Button{Text: item, Submit: func() {
+			editor.err = ""
+			submitter.SendForm(editor.entry(submitter, i))
+		}})
+	}
+	if editor.Validate != nil {
+		m.AddButtons(Button{Text: "Add item", Submit: func() {
+			editor.err = ""
+			submitter.SendForm(editor.input(submitter))
+		}})
+	}
+	m.AddButtons(Button{Text: "Done", Submit: func() {
+		if editor.Submit != nil {
+			editor.Submit(false, editor.Items)
+		}
+	}})
+	return m
+}
+
+// entry returns the Menu used to move or remove the item at the index passed. The editor is sent to the submitter
+// passed again once a button is clicked or the menu is closed.
+func (editor *ListEditor) entry(submitter form.Submitter, i int) *Menu {
+	m := &Menu{Title: editor.Title, Content: editor.Items[i], Submit: func(bool) {
+		submitter.SendForm(editor)
+	}}
+	if i > 0 {
+		m.AddButtons(Button{Text: "Move up", Submit: func() {
+			editor.swap(i, i-1)
+		}})
+	}
+	if i < len(editor.Items)-1 {
+		m.AddButtons(Button{Text: "Move down", Submit: func() {
+			editor.swap(i, i+1)
+		}})
+	}
+	m.AddButtons(Button{Text: "§cRemove", Submit: func() {
+		editor.Items = append(editor.Items[:i:i], editor.Items[i+1:]...)
+	}})
+	m.AddButtons(Button{Text: "Back"})
+	return m
+}
+
+// input returns the Custom form used to add a new item to the end of the list. The editor is sent to the submitter
+// passed again once the form is submitted or closed.
+func (editor *ListEditor) input(submitter form.Submitter) *Custom {
+	return &Custom{Title: editor.Title, Elements: []Element{Input{Text: "Item", Submit: func(text string) {
+		text = strings.TrimSpace(text)
+		if err := editor.Validate(text); err != nil {
+			editor.err = err.Error()
+			return
+		}
+		editor.Items = append(editor.Items[:len(editor.Items):len(editor.Items)], text)
+	}}}, Submit: func(bool, []any) {
+		submitter.SendForm(editor)
+	}}
+}
+
+// swap swaps the items at the indices passed. The items are copied first, so that the slice originally passed to the
+// editor is not modified.
+func (editor *ListEditor) swap(i, j int) {
+	items := append([]string(nil), editor.Items...)
+	items[i], items[j] = items[j], items[i]
+	editor.Items = items
+}
@@ -0,0 +1,120 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+)
+
+// Macro is a recorded path through a series of forms, such as the buttons clicked and values entered by a staff
+// member in admin menus. A Macro may be played back to repeat the same path programmatically, for example against
+// other targets. Macros may be stored as JSON.
+type Macro struct {
+	// Responses holds the responses submitted to every form of the path, in order. A null response is a form that was
+	// closed.
+	Responses []json.RawMessage `json:"responses"`
+}
+
+// Recorder records the path of a submitter through a series of forms as a Macro. A Recorder is safe for concurrent
+// use.
+type Recorder struct {
+	mu        sync.Mutex
+	responses []json.RawMessage
+}
+
+// Record wraps a form so that the response submitted to it is recorded. Forms that are sent to the submitter from the
+// handlers of the form are recorded too, as long as they are sent using the submitter passed to the handlers. Note
+// that handlers are passed a wrapped submitter, so type assertions on the submitter, such as to a *player.Player,
+// fail while recording.
+func (r *Recorder) Record(f form.Form) form.Form {
+	return recordedForm{Form: f, r: r}
+}
+
+// Macro returns the Macro recorded so far.
+func (r *Recorder) Macro() Macro {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Macro{Responses: append([]json.RawMessage(nil), r.responses...)}
+}
+
+// Reset clears the responses recorded so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = nil
+}
+
+// record records the response passed.
+func (r *Recorder) record(data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if data == nil {
+		r.responses = append(r.responses, json.RawMessage("null"))
+		return
+	}
+	r.responses = append(r.responses, append(json.RawMessage(nil), data...))
+}
+
+// recordedForm is a form wrapped by a Recorder.
+type recordedForm struct {
+	form.Form
+	r *Recorder
+}
+
+// SubmitJSON ...
+func (f recordedForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	f.r.record(data)
+	return f.Form.SubmitJSON(data, recordingSubmitter{Submitter: submitter, r: f.r})
+}
+
+// recordingSubmitter is a submitter that records the forms sent to it using a Recorder.
+type recordingSubmitter struct {
+	form.Submitter
+	r *Recorder
+}
+
+// SendForm ...
+func (s recordingSubmitter) SendForm(f form.Form) {
+	s.Submitter.SendForm(s.r.Record(f))
+}
+
+// Play plays back the macro, starting by submitting its first response to the form passed. Every form that the
+// handlers send to the submitter passed to them is submitted the next response of the macro instead of being sent.
+// Forms sent after the last response was used are dropped. Play returns the first error returned while submitting a
+// response, or an error if not all responses of the macro were used.
+func (m Macro) Play(f form.Form, submitter form.Submitter) error {
+	if len(m.Responses) == 0 {
+		return nil
+	}
+	p := &macroPlayer{Submitter: submitter, responses: m.Responses}
+	p.SendForm(f)
+	if p.err != nil {
+		return p.err
+	} else if len(p.responses) != 0 {
+		return fmt.Errorf("macro has %v unused responses", len(p.responses))
+	}
+	return nil
+}
+
+// macroPlayer is a submitter that submits the responses of a Macro to the forms sent to it.
+type macroPlayer struct {
+	form.Submitter
+	responses []json.RawMessage
+	err       error
+}
+
+// SendForm ...
+func (p *macroPlayer) SendForm(f form.Form) {
+	if len(p.responses) == 0 || p.err != nil {
+		return
+	}
+	data := []byte(p.responses[0])
+	p.responses = p.responses[1:]
+	if string(data) == "null" {
+		data = nil
+	}
+	if err := f.SubmitJSON(data, p); err != nil {
+		p.err = err
+	}
+}
@@ -0,0 +1,177 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"strings"
+)
+
+// MapEditor represents a form used to edit a set of key-value pairs of which the keys are not known in advance, such
+// as the settings of a plugin. The entries are shown as buttons, sorted by key. Clicking an entry opens a menu to edit
+// its value or to delete it after confirmation. The editor is sent to the player again after every change, until the
+// player presses the done button or closes the form. Changes are made to a copy of the entries, which is only applied
+// once the player presses the done button.
+type MapEditor struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Entries holds the entries of the editor. The changes of the player are applied to it once the player presses
+	// the done button, and are discarded if the player closes the form.
+	Entries map[string]string
+	// Validate is called with every entry the player tries to add or edit. If it returns an error, the entry is not
+	// changed and the error is displayed to the player. Validate may be nil, in which case any entry with a non-empty
+	// key is accepted.
+	Validate func(key, value string) error
+	// Submit is called with the final entries when the player presses the done button or closes the form. If the form
+	// was closed, the entries passed are the Entries without the changes of the player.
+	Submit func(closed bool, entries map[string]string)
+
+	err string
+	// changed holds the entries with the changes of the player applied. It is nil if the player did not change any
+	// entries yet.
+	changed map[string]string
+}
+
+// MarshalJSON ...
+func (editor *MapEditor) MarshalJSON() ([]byte, error) {
+	return editor.menu(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (editor *MapEditor) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return editor.menu(submitter).SubmitJSON(data, submitter)
+}
+
+// menu returns the Menu that the editor currently consists of. Clicking its buttons updates the editor and sends it
+// to the submitter passed again.
+func (editor *MapEditor) menu(submitter form.Submitter) *Menu {
+	content := editor.Content
+	if editor.err != "" {
+		content = strings.TrimSpace(content + "\n\n§c" + editor.err)
+	}
+	entries := editor.entries()
+	m := &Menu{Title: editor.Title, Content: content, Submit: func(closed bool) {
+		if !closed {
+			return
+		}
+		editor.changed = nil
+		if editor.Submit != nil {
+			editor.Submit(true, editor.Entries)
+		}
+	}}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		key := key
+		m.AddButtons(Button{Text: key + "\n§8" + entries[key], Submit: func() {
+			editor.err = ""
+			submitter.SendForm(editor.entry(submitter, key))
+		}})
+	}
+	m.AddButtons(Button{Text: "Add entry", Submit: func() {
+		editor.err = ""
+		submitter.SendForm(editor.input(submitter, "", ""))
+	}})
+	m.AddButtons(Button{Text: "Done", Submit: func() {
+		if editor.changed != nil {
+			editor.Entries, editor.changed = editor.changed, nil
+		}
+		if editor.Submit != nil {
+			editor.Submit(false, editor.Entries)
+		}
+	}})
+	return m
+}
+
+// entries returns the entries of the editor with the changes of the player applied.
+func (editor *MapEditor) entries() map[string]string {
+	if editor.changed != nil {
+		return editor.changed
+	}
+	return editor.Entries
+}
+
+// change returns the entries of the editor with the changes of the player applied, so that they may be changed. The
+// Entries are copied the first time the player changes an entry.
+func (editor *MapEditor) change() map[string]string {
+	if editor.changed == nil {
+		editor.changed = make(map[string]string, len(editor.Entries))
+		for key, value := range editor.Entries {
+			editor.changed[key] = value
+		}
+	}
+	return editor.changed
+}
+
+// entry returns the Menu used to edit or delete the entry with the key passed. The editor is sent to the submitter
+// passed again when the menu is closed or the back button is clicked.
+func (editor *MapEditor) entry(submitter form.Submitter, key string) *Menu {
+	back := func() {
+		submitter.SendForm(editor)
+	}
+	value := editor.entries()[key]
+	return &Menu{Title: editor.Title, Content: key + ": " + value, Buttons: []Button{
+		{Text: "Edit value", Submit: func() {
+			submitter.SendForm(editor.input(submitter, key, value))
+		}},
+		{Text: "§cDelete", Submit: func() {
+			submitter.SendForm(&Modal{
+				Title:   editor.Title,
+				Content: fmt.Sprintf("Are you sure you want to delete %v?", key),
+				Button1: Button{Text: "Delete", Submit: func() { delete(editor.change(), key) }},
+				Button2: Button{Text: "Cancel"},
+				Submit:  func(bool) { back() },
+			})
+		}},
+		{Text: "Back", Submit: back},
+	}, Submit: func(closed bool) {
+		if closed {
+			back()
+		}
+	}}
+}
+
+// input returns the Custom form used to add a new entry, or to edit the value of an existing entry if key is not
+// empty. The editor is sent to the submitter passed again once the form is submitted or closed.
+func (editor *MapEditor) input(submitter form.Submitter, key, value string) *Custom {
+	c := &Custom{Title: editor.Title, Submit: func(closed bool, values []any) {
+		if !closed {
+			k, _ := values[0].(string)
+			v, _ := values[len(values)-1].(string)
+			if key != "" {
+				k = key
+			}
+			k = strings.TrimSpace(k)
+			if err := editor.set(k, v, key == ""); err != nil {
+				editor.err = err.Error()
+			}
+		}
+		submitter.SendForm(editor)
+	}}
+	if key == "" {
+		c.AddElements(Input{Text: "Key"})
+	}
+	c.AddElements(Input{Text: "Value", Default: value})
+	return c
+}
+
+// set validates the entry passed and sets it in the editor. If add is true, the entry may not exist yet.
+func (editor *MapEditor) set(key, value string, add bool) error {
+	if key == "" {
+		return fmt.Errorf("key may not be empty")
+	} else if _, ok := editor.entries()[key]; ok && add {
+		return fmt.Errorf("an entry with key %v already exists", key)
+	}
+	if editor.Validate != nil {
+		if err := editor.Validate(key, value); err != nil {
+			return err
+		}
+	}
+	editor.change()[key] = value
+	return nil
+}
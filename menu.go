@@ -13,6 +13,12 @@ type Menu struct {
 	Title string
 	// Content is the content that is displayed underneath the title and before any buttons.
 	Content string
+	// ContentData returns the values of the placeholders in the Content for the submitter passed, such as '{kills}',
+	// which are replaced when the form is sent using For. ContentData may be nil.
+	ContentData func(submitter form.Submitter) map[string]any
+	// Elements is a slice of elements displayed underneath the content and before the Buttons. Unlike Buttons, it may
+	// hold labels, headers and dividers in between buttons, as well as any other type implementing MenuElement.
+	Elements []MenuElement
 	// Buttons is a slice of buttons that can be clicked by a player. There must be at least one button for the client
 	// to render the form.
 	Buttons []Button
@@ -21,13 +27,141 @@ type Menu struct {
 	Submit func(closed bool)
 }
 
+// NewMenu creates a new Menu form with the title, content and buttons passed. The Submit function of the form may be
+// set on the Menu returned.
+func NewMenu(title, content string, buttons ...Button) *Menu {
+	return &Menu{Title: title, Content: content, Buttons: buttons}
+}
+
+// MenuElement represents an element that may be added to a Menu. Button, Label, Header and Divider implement
+// MenuElement. Other types may implement it to add their own kind of element to menus.
+type MenuElement interface {
+	// MarshalJSON encodes the element as an object in the 'elements' of the menu. The object must hold a 'type' field.
+	// Clickable elements are also sent in the 'buttons' of the menu, for clients that do not support 'elements'.
+	json.Marshaler
+	// Clickable reports if the element is a button that may be clicked by a player. The response of a player holds the
+	// index of the clicked element among all clickable elements of the menu.
+	Clickable() bool
+	// Click is called when a player clicks the element. It is only called for clickable elements, and always before
+	// the Menu's Submit.
+	Click()
+}
+
 // Button appends a button to the bottom of the form.
+//
+// Deprecated: Use AddButtons, which appends any number of buttons at once.
 func (form *Menu) Button(button Button) {
 	form.Buttons = append(form.Buttons, button)
 }
 
+// AddButtons appends the buttons passed to the bottom of the form, in order.
+func (form *Menu) AddButtons(buttons ...Button) {
+	form.Buttons = append(form.Buttons, buttons...)
+}
+
+// ButtonProvider is a MenuElement that provides buttons to a Menu, such as for every player online or every open
+// ticket. A Menu expands a ButtonProvider into the buttons it returns every time the Menu is sent, so that menus
+// listing volatile data are always fresh without the whole form having to be rebuilt. A Menu holding a ButtonProvider
+// should be sent using Menu.Send, so that the button clicked is resolved against the buttons provided when the Menu
+// was sent to that player rather than the buttons provided by the time it is submitted.
+type ButtonProvider func() []Button
+
+// MarshalJSON returns an error: A ButtonProvider is expanded by the Menu it was added to and cannot be marshaled by
+// itself.
+func (ButtonProvider) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("button provider must be added to a menu to be marshaled")
+}
+
+// Clickable ...
+func (ButtonProvider) Clickable() bool {
+	return false
+}
+
+// Click ...
+func (ButtonProvider) Click() {}
+
+// Section is a group of buttons in a Menu under a header, used to structure large menus.
+type Section struct {
+	// Header is the text of the header displayed above the buttons of the section. If empty, no header is displayed.
+	// The text may contain Minecraft formatting codes.
+	Header string
+	// Buttons holds the buttons of the section.
+	Buttons []Button
+}
+
+// AddSections appends the sections passed to the Elements of the form, in order. Every section is added as its header,
+// followed by its buttons, and sections are separated by dividers. Because sections are added to the Elements, they are
+// displayed before the Buttons of the form.
+func (form *Menu) AddSections(sections ...Section) {
+	for _, section := range sections {
+		if len(form.Elements) != 0 {
+			form.Elements = append(form.Elements, Divider{})
+		}
+		if section.Header != "" {
+			form.Elements = append(form.Elements, Header{Text: section.Header})
+		}
+		for _, button := range section.Buttons {
+			form.Elements = append(form.Elements, button)
+		}
+	}
+}
+
+// For returns a copy of the form for the submitter passed, with all buttons removed that are not Visible to the
+// submitter, and with the ContentData and the templates of all TemplateLabels resolved for the submitter. The form
+// returned should be sent to that submitter only.
+func (form *Menu) For(submitter form.Submitter) *Menu {
+	c := *form
+	c.Elements, c.Buttons = nil, nil
+	if form.ContentData != nil {
+		c.Content = Interpolate(form.Content, form.ContentData(submitter))
+	}
+	for _, element := range form.Elements {
+		switch e := unwrap(element).(type) {
+		case TemplateLabel:
+			element = e.Resolve(submitter)
+		case Button:
+			if !e.visible(submitter) {
+				continue
+			}
+		case ButtonProvider:
+			element = ButtonProvider(func() []Button {
+				var buttons []Button
+				for _, button := range e() {
+					if button.visible(submitter) {
+						buttons = append(buttons, button)
+					}
+				}
+				return buttons
+			})
+		}
+		c.Elements = append(c.Elements, element)
+	}
+	for _, button := range form.Buttons {
+		if button.visible(submitter) {
+			c.Buttons = append(c.Buttons, button)
+		}
+	}
+	return &c
+}
+
+// Send sends the menu to the submitter passed. ButtonProviders are expanded once for the send, and the button clicked
+// is resolved against the buttons they provided, so that the menu may be sent to multiple players at the same time.
+func (form *Menu) Send(submitter form.Submitter) {
+	submitter.SendForm(&sentMenu{Menu: form, elements: form.elements()})
+}
+
 // SubmitJSON ...
-func (form *Menu) SubmitJSON(data []byte, _ form.Submitter) error {
+func (form *Menu) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return form.submit(data, submitter, form.elements())
+}
+
+// MarshalJSON ...
+func (form *Menu) MarshalJSON() ([]byte, error) {
+	return form.marshal(form.elements())
+}
+
+// submit submits the response passed, resolving the button clicked against the elements passed.
+func (form *Menu) submit(data []byte, submitter form.Submitter, elements []MenuElement) error {
 	if data == nil {
 		if form.Submit != nil {
 			form.Submit(true)
@@ -39,28 +173,117 @@ func (form *Menu) SubmitJSON(data []byte, _ form.Submitter) error {
 	if err != nil {
 		return fmt.Errorf("cannot parse button index as int: %w", err)
 	}
-	if index >= uint(len(form.Buttons)) {
-		return fmt.Errorf("button index points to inexistent button: %v (only %v buttons present)", index, len(form.Buttons))
+	buttons := clickable(elements)
+	if index >= uint(len(buttons)) {
+		return fmt.Errorf("button index points to inexistent button: %v (only %v buttons present)", index, len(buttons))
 	}
-	button := form.Buttons[index]
-	if button.Submit != nil {
-		button.Submit()
+	if button, ok := unwrap(buttons[index]).(Button); ok {
+		if !button.visible(submitter) {
+			return fmt.Errorf("button %v is not visible to the submitter", index)
+		} else if button.Disabled {
+			if err := button.unavailable(); err != nil {
+				return fmt.Errorf("button %v: %w", index, err)
+			}
+			return nil
+		}
 	}
+	buttons[index].Click()
 	if form.Submit != nil {
 		form.Submit(false)
 	}
 	return nil
 }
 
-// MarshalJSON ...
-func (form *Menu) MarshalJSON() ([]byte, error) {
-	if form.Buttons == nil {
-		form.Buttons = make([]Button, 0)
-	}
-	return json.Marshal(map[string]any{
+// marshal encodes the form with the elements passed.
+func (form *Menu) marshal(elements []MenuElement) ([]byte, error) {
+	m := map[string]any{
 		"type":    "form",
 		"title":   form.Title,
 		"content": form.Content,
-		"buttons": form.Buttons,
-	})
+		"buttons": clickable(elements),
+	}
+	if len(form.Elements) != 0 {
+		typed := make([]json.Marshaler, len(elements))
+		for i, element := range elements {
+			typed[i] = element
+			if b, ok := unwrap(element).(Button); ok {
+				typed[i] = typedButton{Button: b}
+			}
+		}
+		m["elements"] = typed
+	}
+	return json.Marshal(m)
+}
+
+// sentMenu is a Menu sent using Send. It holds the elements of the Menu as they were when it was sent, with all
+// ButtonProviders expanded.
+type sentMenu struct {
+	*Menu
+	elements []MenuElement
+}
+
+// MarshalJSON ...
+func (m *sentMenu) MarshalJSON() ([]byte, error) {
+	return m.marshal(m.elements)
+}
+
+// SubmitJSON ...
+func (m *sentMenu) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return m.submit(data, submitter, m.elements)
+}
+
+// typedButton is a Button as encoded in the 'elements' of a Menu, which, unlike the 'buttons' of the Menu, must hold
+// the 'type' of every element.
+type typedButton struct {
+	Button
+}
+
+// MarshalJSON ...
+func (b typedButton) MarshalJSON() ([]byte, error) {
+	data, err := b.Button.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	m["type"] = "button"
+	return json.Marshal(m)
+}
+
+// elements returns all elements of the form in the order in which they are displayed: The Elements first, followed by
+// the Buttons. ButtonProviders are expanded into the buttons they provide.
+func (form *Menu) elements() []MenuElement {
+	elements := make([]MenuElement, 0, len(form.Elements)+len(form.Buttons))
+	for _, element := range form.Elements {
+		if provider, ok := unwrap(element).(ButtonProvider); ok {
+			for _, button := range provider() {
+				elements = append(elements, button)
+			}
+			continue
+		}
+		elements = append(elements, element)
+	}
+	for _, button := range form.Buttons {
+		elements = append(elements, button)
+	}
+	return elements
+}
+
+// clickable returns all clickable elements of the form, in the order in which they are displayed. The index of an
+// element in the slice returned is the index submitted by the client when it is clicked.
+func (form *Menu) clickable() []MenuElement {
+	return clickable(form.elements())
+}
+
+// clickable returns the clickable elements of the elements passed, in order. The elements passed are not modified.
+func clickable(elements []MenuElement) []MenuElement {
+	out := make([]MenuElement, 0, len(elements))
+	for _, element := range elements {
+		if element.Clickable() {
+			out = append(out, element)
+		}
+	}
+	return out
 }
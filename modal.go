@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+	"time"
 )
 
 // Modal represents a modal form. These forms have a body with text and two buttons at the end, typically one for Yes
@@ -20,6 +22,38 @@ type Modal struct {
 	// Submit is called when the form is closed or if a player clicks a button. This is always called after the clicked
 	// Button's Submit.
 	Submit func(closed bool)
+	// Timeout is the time after which the modal resolves itself as if TimeoutButton was clicked, so that flows such as
+	// votes are not stalled by players that do not answer. If zero, the modal never resolves itself. The timeout starts
+	// when the modal is sent using Send, and every send has a timeout of its own. Modals sent using the SendForm method
+	// of a submitter never resolve themselves. Responses submitted after the timeout are ignored.
+	//
+	// When the modal resolves itself, Close, the Submit of the TimeoutButton, Submit and Error are called on the
+	// goroutine of the timer rather than on the goroutine of the player, so they must be safe to call from any
+	// goroutine, for example by executing any changes to the world through the world's transaction queue. A modal is
+	// only ever resolved once, so these functions are never called for a response submitted at the same time.
+	Timeout time.Duration
+	// TimeoutButton is the button that the modal resolves to after the Timeout: 1 for Button1 and 2 for Button2. If
+	// zero, the modal resolves to Button1.
+	TimeoutButton int
+	// Close is called when the modal resolves itself because of the Timeout, before the button is clicked, so that it
+	// may be closed on the client, for example by sending a ClientBoundCloseForm packet. Close may be nil.
+	Close func()
+	// Error is called with the error returned by clicking the button when the modal resolves itself because of the
+	// Timeout, such as if the button is disabled. It may be nil.
+	Error func(err error)
+}
+
+// Send sends the modal to the submitter passed and starts its Timeout, if it has one.
+func (form *Modal) Send(submitter form.Submitter) {
+	if form.Timeout <= 0 {
+		submitter.SendForm(form)
+		return
+	}
+	m := &timedModal{Modal: form}
+	m.timeout.start(form.Timeout, func() {
+		m.expire(m.Modal.click)
+	})
+	submitter.SendForm(m)
 }
 
 // SubmitJSON ...
@@ -34,10 +68,19 @@ func (form *Modal) SubmitJSON(data []byte, _ form.Submitter) error {
 	if err := json.Unmarshal(data, &value); err != nil {
 		return fmt.Errorf("error parsing JSON as bool: %w", err)
 	}
+	return form.click(value)
+}
+
+// click clicks Button1 if first is true, or Button2 if first is false. If the button clicked is disabled, its
+// Unavailable function is called instead.
+func (form *Modal) click(first bool) error {
 	button := form.Button1
-	if !value {
+	if !first {
 		button = form.Button2
 	}
+	if button.Disabled {
+		return button.unavailable()
+	}
 	if button.Submit != nil {
 		button.Submit()
 	}
@@ -47,13 +90,70 @@ func (form *Modal) SubmitJSON(data []byte, _ form.Submitter) error {
 	return nil
 }
 
+// expire closes the modal after its Timeout and clicks the TimeoutButton using the click function passed, reporting
+// the error returned to the Error function of the modal.
+func (form *Modal) expire(click func(first bool) error) {
+	if form.Close != nil {
+		form.Close()
+	}
+	if err := click(form.TimeoutButton != 2); err != nil && form.Error != nil {
+		form.Error(err)
+	}
+}
+
 // MarshalJSON ...
 func (form *Modal) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"type":    "modal",
 		"title":   form.Title,
 		"content": form.Content,
-		"button1": form.Button1.Text,
-		"button2": form.Button2.Text,
+		"button1": form.Button1.text(),
+		"button2": form.Button2.text(),
+	})
+}
+
+// timedModal is a Modal sent using Send, of which the Timeout was started.
+type timedModal struct {
+	*Modal
+	timeout modalTimeout
+}
+
+// SubmitJSON ...
+func (m *timedModal) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if !m.timeout.resolve() {
+		// The modal already resolved itself because of its timeout.
+		return nil
+	}
+	return m.Modal.SubmitJSON(data, submitter)
+}
+
+// modalTimeout holds the timer of the Timeout of a modal that was sent. Every send of a modal has a modalTimeout of its
+// own, which makes sure that the modal is resolved only once: either by the timer or by a response submitted.
+type modalTimeout struct {
+	once  sync.Once
+	timer *time.Timer
+}
+
+// start starts a timer that resolves the modal and calls the function passed on the goroutine of the timer after the
+// duration passed, unless the modal is resolved before. If the duration is zero, no timer is started.
+func (t *modalTimeout) start(d time.Duration, f func()) {
+	if d <= 0 {
+		return
+	}
+	t.timer = time.AfterFunc(d, func() {
+		t.once.Do(f)
 	})
 }
+
+// resolve marks the modal as resolved and stops the timer. It returns false if the modal was already resolved. If the
+// timer is resolving the modal at the same time, resolve waits until the function passed to start returns.
+func (t *modalTimeout) resolve() bool {
+	resolved := false
+	t.once.Do(func() {
+		resolved = true
+	})
+	if resolved && t.timer != nil {
+		t.timer.Stop()
+	}
+	return resolved
+}
@@ -0,0 +1,221 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ModelEditor represents a form used to create or edit a record, such as a model persisted in a database. The fields
+// of the form are generated from the exported fields of the struct that Model points to, which may be strings, bools,
+// integers, floats or pointers to any of those. Pointer fields are nullable: Submitting an empty value for them sets
+// the field to nil.
+//
+// The form rendered for a field may be changed using a `form` struct tag holding options separated by semicolons:
+//
+//	Name  string  `form:"text:Display name;required"`
+//	Rank  string  `form:"options:member|moderator|admin"`
+//	Level int     `form:"min:1;max:100;step:1"`
+//	Notes *string `form:"placeholder:None"`
+//	ID    int     `form:"-"`
+//
+// The options 'name', 'text', 'placeholder', 'min', 'max' and 'step' correspond to the fields of a Field, 'options'
+// turns a string field into a dropdown and 'required' prevents a string field from being submitted empty. Fields
+// tagged with `form:"-"` are not displayed.
+type ModelEditor struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Model is a pointer to the struct edited. The current values of its fields are used as defaults of the form. When
+	// the form is submitted, the values submitted are written back to the struct before Save is called.
+	Model any
+	// Save is called with Model after the values submitted were written back to it. An error returned by Save is
+	// returned from SubmitJSON. Save is not called if the form is closed.
+	Save func(model any) error
+}
+
+// Schema returns the Schema of the form generated from Model. It returns an error if Model is not a pointer to a
+// struct or if one of its fields cannot be edited using a form.
+func (editor *ModelEditor) Schema() (Schema, error) {
+	s, _, err := editor.schema()
+	return s, err
+}
+
+// schema returns the Schema of the form generated from Model, together with the index of the struct field that
+// produced each Field of the Schema.
+func (editor *ModelEditor) schema() (Schema, []int, error) {
+	v := reflect.ValueOf(editor.Model)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return Schema{}, nil, fmt.Errorf("model must be a pointer to a struct, got %T", editor.Model)
+	}
+	v = v.Elem()
+	s := Schema{Title: editor.Title}
+	var indices []int
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() || sf.Tag.Get("form") == "-" {
+			continue
+		}
+		f, err := modelField(sf, v.Field(i))
+		if err != nil {
+			return Schema{}, nil, fmt.Errorf("model field %v: %w", sf.Name, err)
+		}
+		s.Fields, indices = append(s.Fields, f), append(indices, i)
+	}
+	return s, indices, nil
+}
+
+// MarshalJSON ...
+func (editor *ModelEditor) MarshalJSON() ([]byte, error) {
+	s, err := editor.Schema()
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.Render(nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.MarshalJSON()
+}
+
+// SubmitJSON ...
+func (editor *ModelEditor) SubmitJSON(data []byte, submitter form.Submitter) error {
+	s, indices, err := editor.schema()
+	if err != nil {
+		return err
+	}
+	var submitted map[string]any
+	c, err := s.Render(func(closed bool, values map[string]any) {
+		submitted = values
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.SubmitJSON(data, submitter); err != nil || submitted == nil {
+		return err
+	}
+	v := reflect.ValueOf(editor.Model).Elem()
+	for i, f := range s.Fields {
+		if err := setModelField(v.Field(indices[i]), submitted[f.Name]); err != nil {
+			return fmt.Errorf("model field %v: %w", f.Name, err)
+		}
+	}
+	if editor.Save != nil {
+		if err := editor.Save(editor.Model); err != nil {
+			return fmt.Errorf("save model: %w", err)
+		}
+	}
+	return nil
+}
+
+// modelField returns the Field used to edit the struct field passed, which currently holds the value v.
+func modelField(sf reflect.StructField, v reflect.Value) (Field, error) {
+	f := Field{Name: sf.Name}
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		f.Nullable, t = true, t.Elem()
+		if !v.IsNil() {
+			v = v.Elem()
+		}
+	}
+	for _, opt := range strings.Split(sf.Tag.Get("form"), ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), ":")
+		var err error
+		switch key {
+		case "":
+		case "name":
+			f.Name = value
+		case "text":
+			f.Text = value
+		case "placeholder":
+			f.Placeholder = value
+		case "required":
+			f.Required = true
+		case "options":
+			f.Options = strings.Split(value, "|")
+		case "min":
+			f.Min, err = strconv.ParseFloat(value, 64)
+		case "max":
+			f.Max, err = strconv.ParseFloat(value, 64)
+		case "step":
+			f.StepSize, err = strconv.ParseFloat(value, 64)
+		default:
+			return f, fmt.Errorf("unknown form tag option %v", key)
+		}
+		if err != nil {
+			return f, fmt.Errorf("form tag option %v: %w", key, err)
+		}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		f.Type = FieldString
+		if len(f.Options) != 0 {
+			f.Type = FieldEnum
+		}
+	case reflect.Bool:
+		f.Type = FieldBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f.Type = FieldInteger
+	case reflect.Float32, reflect.Float64:
+		f.Type = FieldNumber
+	default:
+		return f, fmt.Errorf("type %v cannot be edited using a form", sf.Type)
+	}
+	if v.Kind() == reflect.Pointer {
+		// The field is a nil pointer, so there is no default value.
+		return f, nil
+	}
+	switch f.Type {
+	case FieldString, FieldEnum:
+		f.Default = v.String()
+	case FieldBool:
+		f.Default = v.Bool()
+	case FieldInteger:
+		if v.CanInt() {
+			f.Default = int(v.Int())
+		} else {
+			f.Default = int(v.Uint())
+		}
+	case FieldNumber:
+		f.Default = v.Float()
+	}
+	return f, nil
+}
+
+// setModelField sets the struct field v to the value passed, as submitted for the Field returned by modelField.
+func setModelField(v reflect.Value, value any) error {
+	if v.Kind() == reflect.Pointer {
+		if value == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch n := value.(type) {
+	case string:
+		v.SetString(n)
+	case bool:
+		v.SetBool(n)
+	case float64:
+		if v.OverflowFloat(n) {
+			return fmt.Errorf("value %v overflows %v", n, v.Type())
+		}
+		v.SetFloat(n)
+	case int:
+		if v.CanInt() {
+			if v.OverflowInt(int64(n)) {
+				return fmt.Errorf("value %v overflows %v", n, v.Type())
+			}
+			v.SetInt(int64(n))
+		} else {
+			if n < 0 || v.OverflowUint(uint64(n)) {
+				return fmt.Errorf("value %v overflows %v", n, v.Type())
+			}
+			v.SetUint(uint64(n))
+		}
+	}
+	return nil
+}
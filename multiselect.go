@@ -0,0 +1,75 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MultiSelect represents a group of options of which any number may be selected. It is rendered as one toggle per
+// option. Submitters cannot submit fewer than MinSelected or more than MaxSelected options.
+type MultiSelect struct {
+	// Text is the text displayed in a label over the toggles. If empty, no label is added. The text may contain
+	// Minecraft formatting codes.
+	Text string
+	// Options holds the options that may be selected, displayed as the text of each toggle.
+	Options []string
+	// Defaults holds the indices of the options selected by default.
+	Defaults []int
+	// MinSelected is the minimum amount of options that must be selected.
+	MinSelected int
+	// MaxSelected is the maximum amount of options that may be selected. If zero, all options may be selected.
+	MaxSelected int
+	// Submit is called with the indices of the options selected by the player, in ascending order, whenever they
+	// submit the form. If the form is closed, this method is not called. This is always called before the Form's
+	// Submit.
+	Submit func(selected []int)
+}
+
+// MarshalJSON ...
+func (m MultiSelect) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.elements())
+}
+
+// elements ...
+func (m MultiSelect) elements() []Element {
+	var elements []Element
+	if m.Text != "" {
+		elements = append(elements, Label{Text: m.Text})
+	}
+	defaults := make(map[int]bool, len(m.Defaults))
+	for _, i := range m.Defaults {
+		defaults[i] = true
+	}
+	for i, option := range m.Options {
+		elements = append(elements, Toggle{Text: option, Default: defaults[i]})
+	}
+	return elements
+}
+
+// SubmitValue ...
+func (m MultiSelect) SubmitValue(value any) error {
+	if m.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) < len(m.Options) {
+		return fmt.Errorf("value %v is not allowed for multi select element", value)
+	}
+	selected := make([]int, 0, len(m.Options))
+	for i, v := range values[len(values)-len(m.Options):] {
+		enabled, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("value %v is not allowed for toggle element", v)
+		}
+		if enabled {
+			selected = append(selected, i)
+		}
+	}
+	if len(selected) < m.MinSelected {
+		return fmt.Errorf("%v options selected, at least %v must be selected", len(selected), m.MinSelected)
+	} else if m.MaxSelected != 0 && len(selected) > m.MaxSelected {
+		return fmt.Errorf("%v options selected, at most %v may be selected", len(selected), m.MaxSelected)
+	}
+	m.Submit(selected)
+	return nil
+}
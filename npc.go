@@ -0,0 +1,103 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// MaxNpcButtons is the maximum amount of buttons of an NpcDialogue.
+const MaxNpcButtons = 6
+
+// NpcDialogue represents the dialogue of an NPC entity. It is displayed to a player with an NPCDialogue packet, as
+// returned by Packet, and the NPCRequest packets sent by the client when a button is clicked or the dialogue is closed
+// are handled by HandleRequest. Because dragonfly does not handle these packets itself, they must be sent and
+// intercepted by the server or proxy, for example through a packet handler.
+type NpcDialogue struct {
+	// Scene is the name of the scene of the dialogue, which identifies the dialogue in the requests of the client.
+	Scene string
+	// Name is the name of the NPC displayed above the dialogue.
+	Name string
+	// Dialogue is the text of the dialogue. It may contain Minecraft formatting codes.
+	Dialogue string
+	// Buttons holds the buttons of the dialogue. A dialogue may have at most MaxNpcButtons buttons. Their images are
+	// not displayed.
+	Buttons []Button
+	// Submit is called when the dialogue is closed or if a player clicks a button. This is always called after the
+	// clicked Button's Submit.
+	Submit func(closed bool)
+}
+
+// npcAction is a single action in the ActionJSON of an NPCDialogue packet.
+type npcAction struct {
+	ButtonName string `json:"button_name"`
+	Data       []any  `json:"data"`
+	Mode       int    `json:"mode"`
+	Text       string `json:"text"`
+	Type       int    `json:"type"`
+}
+
+// Packet returns the NPCDialogue packet that opens the dialogue for the NPC with the entity unique ID passed.
+func (d *NpcDialogue) Packet(entityUniqueID int64) (*packet.NPCDialogue, error) {
+	if len(d.Buttons) > MaxNpcButtons {
+		return nil, fmt.Errorf("npc dialogue has %v buttons, exceeding the maximum of %v", len(d.Buttons), MaxNpcButtons)
+	}
+	actions := make([]npcAction, len(d.Buttons))
+	for i, b := range d.Buttons {
+		// Actions of type 1 are commands. Commands are not needed, as clicks are handled through HandleRequest.
+		actions[i] = npcAction{ButtonName: b.text(), Data: []any{}, Type: 1}
+	}
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding npc actions: %w", err)
+	}
+	return &packet.NPCDialogue{
+		EntityUniqueID: uint64(entityUniqueID),
+		ActionType:     packet.NPCDialogueActionOpen,
+		Dialogue:       d.Dialogue,
+		SceneName:      d.Scene,
+		NPCName:        d.Name,
+		ActionJSON:     string(data),
+	}, nil
+}
+
+// ClosePacket returns the NPCDialogue packet that closes the dialogue for the NPC with the entity unique ID passed.
+func (d *NpcDialogue) ClosePacket(entityUniqueID int64) *packet.NPCDialogue {
+	return &packet.NPCDialogue{
+		EntityUniqueID: uint64(entityUniqueID),
+		ActionType:     packet.NPCDialogueActionClose,
+		SceneName:      d.Scene,
+	}
+}
+
+// HandleRequest handles an NPCRequest packet sent by the client for the dialogue. Clicking a button calls its Submit
+// and the Submit of the dialogue, and closing the dialogue calls the Submit of the dialogue with closed set to true.
+// Requests for other scenes and requests of other types are ignored, in which case HandleRequest returns false.
+func (d *NpcDialogue) HandleRequest(pk *packet.NPCRequest) (bool, error) {
+	if pk.SceneName != d.Scene {
+		return false, nil
+	}
+	switch pk.RequestType {
+	case packet.NPCRequestActionExecuteAction:
+		index := int(pk.ActionType)
+		if index >= len(d.Buttons) {
+			return true, fmt.Errorf("button index points to inexistent button: %v (only %v buttons present)", index,
+				len(d.Buttons))
+		}
+		button := d.Buttons[index]
+		if button.Disabled {
+			return true, button.unavailable()
+		}
+		button.Click()
+		if d.Submit != nil {
+			d.Submit(false)
+		}
+		return true, nil
+	case packet.NPCRequestActionExecuteClosingCommands:
+		if d.Submit != nil {
+			d.Submit(true)
+		}
+		return true, nil
+	}
+	return false, nil
+}
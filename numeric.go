@@ -0,0 +1,97 @@
+package form
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumericInput represents a text input box element in which a number must be filled out. Submitters cannot submit
+// text that is not a number, or a number outside the range of the input.
+type NumericInput struct {
+	// Text is the text displayed over the input element. The text may contain Minecraft formatting codes.
+	Text string
+	// Default is the default number filled out in the input.
+	Default float64
+	// Placeholder is the text displayed in the input box if it does not contain any text filled out by the user.
+	Placeholder string
+	// Min and Max are used to specify the minimum and maximum number that may be submitted. If both are zero, any
+	// number may be submitted.
+	Min, Max float64
+	// Submit is called with the number provided by the player whenever they submit the form. If the form is closed,
+	// this method is not called. This is always called before the Form's Submit.
+	Submit func(n float64)
+}
+
+// MarshalJSON ...
+func (i NumericInput) MarshalJSON() ([]byte, error) {
+	return Input{Text: i.Text, Default: formatFloat(i.Default), Placeholder: i.Placeholder}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (i NumericInput) SubmitValue(value any) error {
+	if i.Submit == nil {
+		return nil
+	}
+	n, err := parseInputNumber(value, i.Min, i.Max)
+	if err != nil {
+		return err
+	}
+	i.Submit(n)
+	return nil
+}
+
+// IntInput represents a text input box element in which a whole number must be filled out. Submitters cannot submit
+// text that is not a whole number, or a number outside the range of the input.
+type IntInput struct {
+	// Text is the text displayed over the input element. The text may contain Minecraft formatting codes.
+	Text string
+	// Default is the default number filled out in the input.
+	Default int
+	// Placeholder is the text displayed in the input box if it does not contain any text filled out by the user.
+	Placeholder string
+	// Min and Max are used to specify the minimum and maximum number that may be submitted. If both are zero, any
+	// number may be submitted.
+	Min, Max int
+	// Submit is called with the number provided by the player whenever they submit the form. If the form is closed,
+	// this method is not called. This is always called before the Form's Submit.
+	Submit func(n int)
+}
+
+// MarshalJSON ...
+func (i IntInput) MarshalJSON() ([]byte, error) {
+	return Input{Text: i.Text, Default: strconv.Itoa(i.Default), Placeholder: i.Placeholder}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (i IntInput) SubmitValue(value any) error {
+	if i.Submit == nil {
+		return nil
+	}
+	n, err := parseInputNumber(value, float64(i.Min), float64(i.Max))
+	if err != nil {
+		return err
+	} else if n != math.Trunc(n) || n < math.MinInt || n >= math.MaxInt {
+		return fmt.Errorf("input value %v is not a whole number", n)
+	}
+	i.Submit(int(n))
+	return nil
+}
+
+// parseInputNumber parses the value submitted for a numeric input and checks if it is within the range passed. If min
+// and max are both zero, the range is not checked.
+func parseInputNumber(value any, min, max float64) (float64, error) {
+	text, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("value %v is not allowed for input element", value)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("input value %q is not a number", text)
+	}
+	if (min != 0 || max != 0) && (n < min || n > max) {
+		return 0, fmt.Errorf("input value %v is out of range %v-%v", n, min, max)
+	}
+	return n, nil
+}
@@ -0,0 +1,96 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+)
+
+// Observer mirrors the forms sent to a player, and the responses the player submits, to a watching staff member, such
+// as during a support session. The watcher is sent a read-only preview of every form observed: Submitting the
+// preview does nothing. An Observer is safe for concurrent use.
+type Observer struct {
+	// Watcher is the submitter that previews of the forms observed are sent to.
+	Watcher form.Submitter
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+// Observe wraps a form so that a preview of it is sent to the Watcher when it is sent, and the response submitted to
+// it is sent to the Watcher when it is submitted. Forms that are wrapped after the Observer is stopped are returned
+// as is.
+func (o *Observer) Observe(f form.Form) form.Form {
+	if !o.Watching() {
+		return f
+	}
+	return observedForm{Form: f, o: o}
+}
+
+// Stop stops the Observer, so that no more previews are sent to the Watcher.
+func (o *Observer) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stopped = true
+}
+
+// Watching checks if the Observer was not yet stopped.
+func (o *Observer) Watching() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return !o.stopped && o.Watcher != nil
+}
+
+// preview sends a read-only preview of the form JSON passed to the Watcher.
+func (o *Observer) preview(data []byte) {
+	if !o.Watching() {
+		return
+	}
+	// Forms parsed have no handlers set, so submitting the preview does nothing.
+	f, err := Parse(data)
+	if err != nil {
+		return
+	}
+	o.Watcher.SendForm(f)
+}
+
+// response sends the response passed to the Watcher.
+func (o *Observer) response(data []byte, err error) {
+	if !o.Watching() {
+		return
+	}
+	content := "The form was closed."
+	if data != nil {
+		content = "Response: " + string(data)
+	}
+	if err != nil {
+		content += "\n§cError: " + err.Error() + "§r"
+	}
+	o.Watcher.SendForm(&Modal{
+		Title:   "Observed response",
+		Content: content,
+		Button1: Button{Text: "OK"},
+		Button2: Button{Text: "Close"},
+	})
+}
+
+// observedForm is a form wrapped by an Observer.
+type observedForm struct {
+	form.Form
+	o *Observer
+}
+
+// MarshalJSON ...
+func (f observedForm) MarshalJSON() ([]byte, error) {
+	data, err := f.Form.MarshalJSON()
+	if err == nil {
+		f.o.preview(data)
+	}
+	return data, err
+}
+
+// SubmitJSON ...
+func (f observedForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	err := f.Form.SubmitJSON(data, submitter)
+	f.o.response(data, err)
+	return err
+}
@@ -0,0 +1,124 @@
+package form
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"sort"
+)
+
+// Duplicates returns the indices of all options of the dropdown that are equal to an option before them. Duplicate
+// options look the same to the player, which makes it ambiguous which of them was meant when one is submitted.
+func (d Dropdown) Duplicates() []int {
+	return duplicates(d.Options)
+}
+
+// Deduplicate returns a copy of the dropdown with all duplicate options removed, keeping the first of every option.
+// The DefaultIndex is remapped to the remaining option equal to the default option, and Submit is called with the
+// index of the option in the original Options, so that handlers written for the original options keep working.
+func (d Dropdown) Deduplicate() Dropdown {
+	options, indices := deduplicate(d.Options)
+	c := d
+	c.Options = options
+	for i, original := range indices {
+		if d.DefaultIndex >= 0 && d.DefaultIndex < len(d.Options) && d.Options[original] == d.Options[d.DefaultIndex] {
+			c.DefaultIndex = i
+		}
+	}
+	if d.Submit != nil {
+		c.Submit = func(index int, option string) {
+			d.Submit(indices[index], option)
+		}
+	}
+	return c
+}
+
+// Duplicates returns the indices of all options of the step slider that are equal to an option before them.
+// Duplicate options look the same to the player, which makes it ambiguous which of them was meant when one is
+// submitted.
+func (s StepSlider) Duplicates() []int {
+	return duplicates(s.Options)
+}
+
+// Deduplicate returns a copy of the step slider with all duplicate options removed, keeping the first of every
+// option. The DefaultIndex is remapped to the remaining option equal to the default option, and Submit is called with
+// the index of the option in the original Options, so that handlers written for the original options keep working.
+func (s StepSlider) Deduplicate() StepSlider {
+	return StepSlider(Dropdown(s).Deduplicate())
+}
+
+// Sort returns a copy of the dropdown with its options sorted alphabetically according to the collation rules of the
+// language passed, ignoring formatting codes, so that options in languages other than English are sorted correctly.
+// The DefaultIndex is remapped to the same option, and Submit is called with the index of the option in the original
+// Options, so that handlers written for the original options keep working.
+func (d Dropdown) Sort(lang language.Tag) Dropdown {
+	options, indices := sortOptions(d.Options, lang)
+	c := d
+	c.Options = options
+	for i, original := range indices {
+		if original == d.DefaultIndex {
+			c.DefaultIndex = i
+		}
+	}
+	if d.Submit != nil {
+		c.Submit = func(index int, option string) {
+			d.Submit(indices[index], option)
+		}
+	}
+	return c
+}
+
+// Sort returns a copy of the step slider with its options sorted alphabetically according to the collation rules of
+// the language passed, ignoring formatting codes. The DefaultIndex is remapped to the same option, and Submit is
+// called with the index of the option in the original Options, so that handlers written for the original options
+// keep working.
+func (s StepSlider) Sort(lang language.Tag) StepSlider {
+	return StepSlider(Dropdown(s).Sort(lang))
+}
+
+// sortOptions returns the options passed sorted according to the collation rules of the language passed, together
+// with the index in the original options of every option returned.
+func sortOptions(options []string, lang language.Tag) (sorted []string, indices []int) {
+	c := collate.New(lang, collate.Loose)
+	indices = make([]int, len(options))
+	keys := make([]string, len(options))
+	for i, option := range options {
+		indices[i], keys[i] = i, text.Clean(option)
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return c.CompareString(keys[indices[i]], keys[indices[j]]) < 0
+	})
+	sorted = make([]string, len(options))
+	for i, original := range indices {
+		sorted[i] = options[original]
+	}
+	return sorted, indices
+}
+
+// duplicates returns the indices of all options that are equal to an option before them.
+func duplicates(options []string) []int {
+	var indices []int
+	seen := make(map[string]struct{}, len(options))
+	for i, option := range options {
+		if _, ok := seen[option]; ok {
+			indices = append(indices, i)
+			continue
+		}
+		seen[option] = struct{}{}
+	}
+	return indices
+}
+
+// deduplicate returns the options passed with all duplicates removed, together with the index in the original
+// options of every option returned.
+func deduplicate(options []string) (unique []string, indices []int) {
+	seen := make(map[string]struct{}, len(options))
+	for i, option := range options {
+		if _, ok := seen[option]; ok {
+			continue
+		}
+		seen[option] = struct{}{}
+		unique, indices = append(unique, option), append(indices, i)
+	}
+	return unique, indices
+}
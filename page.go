@@ -0,0 +1,24 @@
+package form
+
+// pageBounds returns the start and end index of the items on the page passed, starting at 0, when n items are split
+// into pages of the size passed, and the total amount of pages. The page is clamped to the pages available. There is
+// always at least one page.
+func pageBounds(n, page, size int) (start, end, pages int) {
+	if size <= 0 {
+		size = n
+	}
+	pages = 1
+	if size > 0 && n > size {
+		pages = (n + size - 1) / size
+	}
+	if page < 0 {
+		page = 0
+	} else if page >= pages {
+		page = pages - 1
+	}
+	start, end = page*size, page*size+size
+	if end > n {
+		end = n
+	}
+	return start, end, pages
+}
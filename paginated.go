@@ -0,0 +1,125 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// PaginatedDropdown represents a form used to select one of a very large list of options, such as hundreds of
+// items, which would be unusable in a single dropdown. The player first selects a page of options in a menu, either
+// a category if Category is set or a chunk of PageSize options, after which the options on that page are shown in a
+// dropdown. The option selected is submitted with its index in the full list of options.
+type PaginatedDropdown struct {
+	// Title is the title of the forms.
+	Title string
+	// Text is the text displayed over the dropdown element. The text may contain Minecraft formatting codes.
+	Text string
+	// Options holds the options that may be selected.
+	Options []string
+	// PageSize is the maximum amount of options displayed in the dropdown at once. If zero, pages of 50 options are
+	// used. It is not used if Category is set.
+	PageSize int
+	// Category returns the category of the option passed. If not nil, the options are split into pages by their
+	// category instead of in chunks of PageSize options, in the order in which the categories first appear.
+	Category func(option string) string
+	// Submit is called with the index and the text of the option selected by the player. If the form is closed, this
+	// method is not called.
+	Submit func(index int, option string)
+	// Close is called if the player closes the menu used to select a page. Closing the dropdown of a page sends the
+	// menu to the player again instead. Close may be nil.
+	Close func()
+}
+
+// MarshalJSON ...
+func (d *PaginatedDropdown) MarshalJSON() ([]byte, error) {
+	return d.menu(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (d *PaginatedDropdown) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return d.menu(submitter).SubmitJSON(data, submitter)
+}
+
+// menu returns the Menu used to select a page of options. Selecting a page sends the dropdown with the options of the
+// page to the submitter passed.
+func (d *PaginatedDropdown) menu(submitter form.Submitter) *Menu {
+	m := &Menu{Title: d.Title, Content: d.Text, Submit: func(closed bool) {
+		if closed && d.Close != nil {
+			d.Close()
+		}
+	}}
+	for _, page := range d.pages() {
+		page := page
+		m.AddButtons(Button{Text: page.name, Submit: func() {
+			submitter.SendForm(d.page(submitter, page.indices))
+		}})
+	}
+	return m
+}
+
+// optionPage is a page of options of a PaginatedDropdown.
+type optionPage struct {
+	name    string
+	indices []int
+}
+
+// pages returns the pages that the options of the dropdown are split into.
+func (d *PaginatedDropdown) pages() []optionPage {
+	if d.Category != nil {
+		var pages []optionPage
+		index := make(map[string]int)
+		for i, option := range d.Options {
+			category := d.Category(option)
+			n, ok := index[category]
+			if !ok {
+				n = len(pages)
+				index[category] = n
+				pages = append(pages, optionPage{name: category})
+			}
+			pages[n].indices = append(pages[n].indices, i)
+		}
+		return pages
+	}
+	size := d.PageSize
+	if size <= 0 {
+		size = 50
+	}
+	_, _, n := pageBounds(len(d.Options), 0, size)
+	pages := make([]optionPage, 0, n)
+	for p := 0; p < n; p++ {
+		start, end, _ := pageBounds(len(d.Options), p, size)
+		if start == end {
+			break
+		}
+		page := optionPage{name: fmt.Sprintf("%v - %v\n§8%v-%v", text.Clean(d.Options[start]),
+			text.Clean(d.Options[end-1]), start+1, end)}
+		for i := start; i < end; i++ {
+			page.indices = append(page.indices, i)
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// page returns the Custom form holding the dropdown with the options at the indices passed. Closing it sends the page
+// menu to the submitter passed again.
+func (d *PaginatedDropdown) page(submitter form.Submitter, indices []int) *Custom {
+	options := make([]string, len(indices))
+	for i, index := range indices {
+		options[i] = d.Options[index]
+	}
+	var selected int
+	dropdown := Dropdown{Text: d.Text, Options: options, Submit: func(index int, _ string) {
+		selected = indices[index]
+	}}
+	return &Custom{Title: d.Title, Elements: []Element{dropdown}, Submit: func(closed bool, _ []any) {
+		if closed {
+			submitter.SendForm(d)
+			return
+		}
+		if d.Submit != nil {
+			d.Submit(selected, d.Options[selected])
+		}
+	}}
+}
@@ -0,0 +1,227 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// Parse parses a form from its JSON representation as sent to the client. This is the format in which form libraries
+// for PocketMine and Nukkit, such as FormAPI and libform, store and send forms, so that existing form definitions may
+// be loaded without changes. Depending on the type of the form, Parse returns a *Menu, *Modal or *Custom. None of the
+// Submit functions of the form returned are set.
+func Parse(data []byte) (form.Form, error) {
+	var f struct {
+		Type     string            `json:"type"`
+		Title    string            `json:"title"`
+		Content  json.RawMessage   `json:"content"`
+		Buttons  []parsedButton    `json:"buttons"`
+		Elements []json.RawMessage `json:"elements"`
+		Button1  string            `json:"button1"`
+		Button2  string            `json:"button2"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("error decoding form JSON: %w", err)
+	}
+	switch f.Type {
+	case "form":
+		m := &Menu{Title: f.Title}
+		if err := unmarshalContent(f.Content, &m.Content); err != nil {
+			return nil, err
+		}
+		if len(f.Elements) != 0 {
+			// The elements of a menu hold all of its buttons, so the buttons do not need to be parsed separately.
+			for i, data := range f.Elements {
+				e, err := parseMenuElement(data)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing element %v: %w", i, err)
+				}
+				m.Elements = append(m.Elements, e)
+			}
+			return m, nil
+		}
+		for _, b := range f.Buttons {
+			m.AddButtons(Button{Text: b.Text, Image: b.Image.Data})
+		}
+		return m, nil
+	case "modal":
+		m := &Modal{Title: f.Title, Button1: Button{Text: f.Button1}, Button2: Button{Text: f.Button2}}
+		if err := unmarshalContent(f.Content, &m.Content); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "custom_form":
+		var elements []json.RawMessage
+		if err := unmarshalContent(f.Content, &elements); err != nil {
+			return nil, err
+		}
+		c := &Custom{Title: f.Title}
+		for i, data := range elements {
+			e, err := parseElement(data)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing element %v: %w", i, err)
+			}
+			c.AddElements(e)
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("unknown form type %q", f.Type)
+}
+
+// parsedButton is the JSON representation of a Button.
+type parsedButton struct {
+	Text  string `json:"text"`
+	Image struct {
+		Type string `json:"type"`
+		Data string `json:"data"`
+	} `json:"image"`
+}
+
+// unmarshalContent unmarshals the content of a form into v, if the form has any content.
+func unmarshalContent(content json.RawMessage, v any) error {
+	if len(content) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("error decoding form content: %w", err)
+	}
+	return nil
+}
+
+// parseMenuElement parses a MenuElement of a Menu form from its JSON representation.
+func parseMenuElement(data []byte) (MenuElement, error) {
+	var e struct {
+		Type string `json:"type"`
+		parsedButton
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	switch e.Type {
+	case "button":
+		return Button{Text: e.Text, Image: e.Image.Data}, nil
+	case "label":
+		return Label{Text: e.Text}, nil
+	case "header":
+		return Header{Text: e.Text}, nil
+	case "divider":
+		return Divider{}, nil
+	}
+	return nil, fmt.Errorf("unknown menu element type %q", e.Type)
+}
+
+// parseElement parses an Element of a Custom form from its JSON representation.
+func parseElement(data []byte) (Element, error) {
+	var e struct {
+		Type        string   `json:"type"`
+		Text        string   `json:"text"`
+		Placeholder string   `json:"placeholder"`
+		Default     any      `json:"default"`
+		Min         float64  `json:"min"`
+		Max         float64  `json:"max"`
+		Step        float64  `json:"step"`
+		Options     []string `json:"options"`
+		Steps       []string `json:"steps"`
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	switch e.Type {
+	case "label":
+		return Label{Text: e.Text}, nil
+	case "header":
+		return Header{Text: e.Text}, nil
+	case "divider":
+		return Divider{}, nil
+	case "input":
+		def, _ := e.Default.(string)
+		return Input{Text: e.Text, Default: def, Placeholder: e.Placeholder}, nil
+	case "toggle":
+		def, _ := e.Default.(bool)
+		return Toggle{Text: e.Text, Default: def}, nil
+	case "slider":
+		def, _ := e.Default.(float64)
+		return Slider{Text: e.Text, Min: e.Min, Max: e.Max, StepSize: e.Step, Default: def}, nil
+	case "dropdown":
+		def, _ := e.Default.(float64)
+		return Dropdown{Text: e.Text, Options: e.Options, DefaultIndex: int(def)}, nil
+	case "step_slider":
+		def, _ := e.Default.(float64)
+		return StepSlider{Text: e.Text, Options: e.Steps, DefaultIndex: int(def)}, nil
+	}
+	return nil, fmt.Errorf("unknown element type %q", e.Type)
+}
+
+// Export exports a form to the JSON representation accepted by Parse, which is also the layout used by form
+// libraries for PocketMine and Nukkit to store forms. Only the fields supported by these libraries are exported, so
+// the elements of a Menu other than its buttons and the tooltips of elements are left out. The JSON is indented, so
+// that it may be stored in files edited by hand. Forms of types other than *Menu, *Modal and *Custom cannot be
+// exported.
+func Export(f form.Form) ([]byte, error) {
+	var m map[string]any
+	switch f := f.(type) {
+	case *Menu:
+		buttons := make([]map[string]any, 0, len(f.Buttons))
+		for _, element := range f.elements() {
+			b, ok := unwrap(element).(Button)
+			if !ok {
+				continue
+			}
+			button := map[string]any{"text": b.Text}
+			if b.Image != "" {
+				imageType := "path"
+				if strings.HasPrefix(b.Image, "http:") || strings.HasPrefix(b.Image, "https:") {
+					imageType = "url"
+				}
+				button["image"] = map[string]any{"type": imageType, "data": b.Image}
+			}
+			buttons = append(buttons, button)
+		}
+		m = map[string]any{"type": "form", "title": f.Title, "content": f.Content, "buttons": buttons}
+	case *Modal:
+		m = map[string]any{
+			"type":    "modal",
+			"title":   f.Title,
+			"content": f.Content,
+			"button1": f.Button1.Text,
+			"button2": f.Button2.Text,
+		}
+	case *Custom:
+		content := f.content()
+		elements := make([]map[string]any, len(content))
+		for i, element := range content {
+			e, err := exportElement(element)
+			if err != nil {
+				return nil, fmt.Errorf("error exporting element %v: %w", i, err)
+			}
+			elements[i] = e
+		}
+		m = map[string]any{"type": "custom_form", "title": f.Title, "content": elements}
+	default:
+		return nil, fmt.Errorf("cannot export form of type %T", f)
+	}
+	return json.MarshalIndent(m, "", "    ")
+}
+
+// exportedElementFields holds the fields of elements of a Custom form that are exported by Export.
+var exportedElementFields = []string{"type", "text", "placeholder", "default", "min", "max", "step", "options", "steps"}
+
+// exportElement returns the JSON object of the element passed with only the fields in exportedElementFields.
+func exportElement(element Element) (map[string]any, error) {
+	data, err := element.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	m := make(map[string]any, len(exportedElementFields))
+	for _, field := range exportedElementFields {
+		if v, ok := all[field]; ok {
+			m[field] = v
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,79 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"sync"
+)
+
+// PlayerSelector represents a dropdown used to select one of a list of players, such as the online players in the
+// world of the submitter. The players are obtained from the Players function every time the form is sent, and the
+// player selected is submitted rather than its name. Because the players are stored when the form is sent, a
+// PlayerSelector must be added to a form as a pointer.
+type PlayerSelector struct {
+	// Text is the text displayed over the dropdown element. The text may contain Minecraft formatting codes.
+	Text string
+	// Players returns the players that may be selected, in the order that they are displayed.
+	Players func() []*player.Player
+	// Submit is called with the player selected whenever the form is submitted, if the player is still online. If the
+	// form is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(p *player.Player)
+	// Left is called with the name of the player selected if the player left the server before the form was
+	// submitted. If Left is nil, submitting a player that left returns an error instead.
+	Left func(name string)
+
+	mu      sync.Mutex
+	players []*player.Player
+}
+
+// MarshalJSON ...
+func (s *PlayerSelector) MarshalJSON() ([]byte, error) {
+	var players []*player.Player
+	if s.Players != nil {
+		players = s.Players()
+	}
+	s.mu.Lock()
+	s.players = players
+	s.mu.Unlock()
+
+	options := make([]string, len(players))
+	for i, p := range players {
+		options[i] = p.Name()
+	}
+	if len(options) == 0 {
+		options = []string{"No players"}
+	}
+	return Dropdown{Text: s.Text, Options: options}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (s *PlayerSelector) SubmitValue(value any) error {
+	if s.Submit == nil && s.Left == nil {
+		return nil
+	}
+	s.mu.Lock()
+	players := s.players
+	s.mu.Unlock()
+	if len(players) == 0 {
+		return fmt.Errorf("player selector has no players to select")
+	}
+	var p *player.Player
+	err := Dropdown{Options: make([]string, len(players)), Submit: func(i int, _ string) {
+		p = players[i]
+	}}.SubmitValue(value)
+	if err != nil {
+		return err
+	}
+	// Players that left the server are removed from their world.
+	if p.World() == nil {
+		if s.Left == nil {
+			return fmt.Errorf("player %v left the server", p.Name())
+		}
+		s.Left(p.Name())
+		return nil
+	}
+	if s.Submit != nil {
+		s.Submit(p)
+	}
+	return nil
+}
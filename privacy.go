@@ -0,0 +1,307 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PlayerDataStore is implemented by stores that hold data of players, so that the data of a player may be exported or
+// erased on request, such as under the GDPR. Players are identified by a key, such as their UUID.
+// MemoryReminderStore, MemoryStaffStore, MemoryDismissalStore, MemoryFlowStore, ResponseCache, Usage and Referrals
+// implement PlayerDataStore. Referrals only holds the attempts of players to enter a code: The data in its
+// ReferralStore must be exported and erased by the ReferralStore itself.
+type PlayerDataStore interface {
+	// ExportPlayer returns all data held of the player with the key passed. The data returned must be encodable as
+	// JSON. If no data is held of the player, nil is returned.
+	ExportPlayer(key string) (any, error)
+	// ErasePlayer removes all data held of the player with the key passed.
+	ErasePlayer(key string) error
+}
+
+// DataRequests handles requests of players to export or erase their data across a set of stores, so that such
+// requests may be met without touching every store separately. Players may make requests themselves through the menu
+// sent using Send.
+type DataRequests struct {
+	// Title is the title of the forms. If empty, 'Your data' is used.
+	Title string
+	// Stores holds the stores of which data is exported and erased, by a name used in the export.
+	Stores map[string]PlayerDataStore
+	// Deliver is called with the export of a player that requested it through the menu, and should deliver it to the
+	// player, such as by email or a download link, as exports are too large to display in a form. If nil, players
+	// cannot export their data through the menu.
+	Deliver func(submitter form.Submitter, key string, export []byte) error
+}
+
+// Export returns all data held of the player with the key passed in the Stores, encoded as a JSON object that maps the
+// names of the stores to the data held in them.
+func (d DataRequests) Export(key string) ([]byte, error) {
+	export := make(map[string]any, len(d.Stores))
+	for _, name := range d.names() {
+		data, err := d.Stores[name].ExportPlayer(key)
+		if err != nil {
+			return nil, fmt.Errorf("error exporting data from %v: %w", name, err)
+		}
+		if data != nil {
+			export[name] = data
+		}
+	}
+	return json.MarshalIndent(export, "", "    ")
+}
+
+// Erase removes all data held of the player with the key passed from the Stores. Data is erased from every store, even
+// if erasing it from one of them fails. An error listing the stores that failed is returned.
+func (d DataRequests) Erase(key string) error {
+	var failed []string
+	for _, name := range d.names() {
+		if err := d.Stores[name].ErasePlayer(key); err != nil {
+			failed = append(failed, fmt.Sprintf("%v: %v", name, err))
+		}
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("error erasing data from %v stores: %v", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Send sends the menu used to request an export or erasure of its data to the submitter passed, which is the player
+// with the key passed.
+func (d DataRequests) Send(submitter form.Submitter, key string) {
+	submitter.SendForm(d.menu(submitter, key, ""))
+}
+
+// names returns the names of the Stores, sorted alphabetically.
+func (d DataRequests) names() []string {
+	names := make([]string, 0, len(d.Stores))
+	for name := range d.Stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// title returns the title of the forms.
+func (d DataRequests) title() string {
+	if d.Title == "" {
+		return "Your data"
+	}
+	return d.Title
+}
+
+// menu returns the menu used to request an export or erasure, with the message passed displayed in the content.
+func (d DataRequests) menu(submitter form.Submitter, key, msg string) *Menu {
+	content := strings.TrimSpace("You may request a copy of the data the server holds of you, or have it erased.\n\n" +
+		msg)
+	m := NewMenu(d.title(), content)
+	if d.Deliver != nil {
+		m.AddButtons(Button{Text: "Export my data", Submit: func() {
+			export, err := d.Export(key)
+			if err == nil {
+				err = d.Deliver(submitter, key, export)
+			}
+			if err != nil {
+				submitter.SendForm(d.menu(submitter, key, "§cYour data could not be exported: "+err.Error()))
+				return
+			}
+			submitter.SendForm(d.menu(submitter, key, "§aYour data was exported and will be delivered to you."))
+		}})
+	}
+	m.AddButtons(Button{Text: "Erase my data", Image: TextureTrash, Submit: func() {
+		submitter.SendForm(&Modal{
+			Title:   d.title(),
+			Content: "Are you sure you want to erase all your data? This cannot be undone.",
+			Button1: Button{Text: "Erase", Submit: func() {
+				if err := d.Erase(key); err != nil {
+					submitter.SendForm(d.menu(submitter, key, "§cYour data could not be fully erased: "+err.Error()))
+					return
+				}
+				submitter.SendForm(d.menu(submitter, key, "§aYour data was erased."))
+			}},
+			Button2: Button{Text: "Cancel", Submit: func() {
+				d.Send(submitter, key)
+			}},
+		})
+	}})
+	return m
+}
+
+// ExportPlayer ...
+func (s *MemoryReminderStore) ExportPlayer(key string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snoozed := make(map[string]time.Time)
+	for k, until := range s.m {
+		if k[0] == key {
+			snoozed[k[1]] = until
+		}
+	}
+	if len(snoozed) == 0 {
+		return nil, nil
+	}
+	return snoozed, nil
+}
+
+// ErasePlayer ...
+func (s *MemoryReminderStore) ErasePlayer(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.m {
+		if k[0] == key {
+			delete(s.m, k)
+		}
+	}
+	return nil
+}
+
+// ExportPlayer ...
+func (s *MemoryStaffStore) ExportPlayer(key string) (any, error) {
+	if state, ok := s.State(key); ok {
+		return state, nil
+	}
+	return nil, nil
+}
+
+// ErasePlayer ...
+func (s *MemoryStaffStore) ErasePlayer(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+	return nil
+}
+
+// ExportPlayer ...
+func (s *MemoryDismissalStore) ExportPlayer(key string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var dismissed []string
+	for k := range s.m {
+		if k[0] == key {
+			dismissed = append(dismissed, k[1])
+		}
+	}
+	if len(dismissed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(dismissed)
+	return dismissed, nil
+}
+
+// ErasePlayer ...
+func (s *MemoryDismissalStore) ErasePlayer(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.m {
+		if k[0] == key {
+			delete(s.m, k)
+		}
+	}
+	return nil
+}
+
+// ExportPlayer ...
+func (c *ResponseCache) ExportPlayer(key string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	type response struct {
+		Form     string          `json:"form"`
+		Response json.RawMessage `json:"response"`
+		Expires  time.Time       `json:"expires"`
+	}
+	var responses []response
+	for k, r := range c.responses {
+		if k[0] == key {
+			responses = append(responses, response{Form: k[1], Response: r.data, Expires: r.expires})
+		}
+	}
+	if len(responses) == 0 {
+		return nil, nil
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Form < responses[j].Form
+	})
+	return responses, nil
+}
+
+// ErasePlayer ...
+func (c *ResponseCache) ErasePlayer(key string) error {
+	c.Forget(key)
+	return nil
+}
+
+// ExportPlayer ...
+func (u *Usage) ExportPlayer(key string) (any, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	clicks := make(map[string]map[string]uint64)
+	for k, counts := range u.clicks {
+		if k[0] == key {
+			clicks[k[1]] = make(map[string]uint64, len(counts))
+			for name, n := range counts {
+				clicks[k[1]][name] = n
+			}
+		}
+	}
+	if len(clicks) == 0 {
+		return nil, nil
+	}
+	return clicks, nil
+}
+
+// ErasePlayer ...
+func (u *Usage) ErasePlayer(key string) error {
+	u.Reset(key)
+	return nil
+}
+
+// ExportPlayer ...
+func (s *MemoryFlowStore) ExportPlayer(key string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flows := make(map[string][]string)
+	for k, responses := range s.m {
+		if k[0] == key {
+			// Responses are submitted by the client and may not be valid JSON, so they are exported as text.
+			for _, data := range responses {
+				flows[k[1]] = append(flows[k[1]], string(data))
+			}
+		}
+	}
+	if len(flows) == 0 {
+		return nil, nil
+	}
+	return flows, nil
+}
+
+// ErasePlayer ...
+func (s *MemoryFlowStore) ErasePlayer(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.m {
+		if k[0] == key {
+			delete(s.m, k)
+		}
+	}
+	return nil
+}
+
+// ExportPlayer ...
+func (r *Referrals) ExportPlayer(key string) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempts := r.attempts[key]
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+	return append([]time.Time(nil), attempts...), nil
+}
+
+// ErasePlayer ...
+func (r *Referrals) ErasePlayer(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, key)
+	return nil
+}
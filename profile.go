@@ -0,0 +1,250 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Profile is a compatibility profile for clients that struggle with some features of forms, such as old Android
+// builds. Forms sent to a player with a profile are simplified according to the profile.
+type Profile struct {
+	// NoImages removes the images of all buttons.
+	NoImages bool
+	// MaxOptions is the maximum amount of options of dropdowns and step sliders. Options past the maximum are
+	// removed. If zero, options are not removed.
+	MaxOptions int
+	// NoHeaders replaces headers and dividers, which are not supported by older clients, with labels.
+	NoHeaders bool
+	// NoTooltips removes the tooltips of all elements, for clients that fail to render elements with a tooltip.
+	NoTooltips bool
+	// Quirks holds the quirks of the responses of the client, which are corrected before a response is submitted to
+	// the form, so that the validation of the form does not reject them.
+	Quirks Quirk
+}
+
+// Quirk is a set of quirks in the form responses of specific client versions. Quirks may be combined using |.
+type Quirk int
+
+const (
+	// QuirkMisalignedSteps is the quirk of clients that submit slider values that do not lie exactly on a step of the
+	// slider, such as 0.30000000000000004. Values are rounded to the nearest step.
+	QuirkMisalignedSteps Quirk = 1 << iota
+	// QuirkTrailingNulls is the quirk of clients that leave out the null values of trailing labels, headers and
+	// dividers from responses. Missing values are filled with null.
+	QuirkTrailingNulls
+	// QuirkStringNumbers is the quirk of clients that submit the values of sliders, dropdowns and step sliders as
+	// strings, such as "3". Such values are converted to numbers.
+	QuirkStringNumbers
+)
+
+// QuirkRange holds the quirks of a range of client protocol versions.
+type QuirkRange struct {
+	// MinProtocol and MaxProtocol are the lowest and highest protocol version of the range, inclusive.
+	MinProtocol, MaxProtocol int
+	// Quirks holds the quirks of clients in the range.
+	Quirks Quirk
+}
+
+// QuirkTable maps ranges of client protocol versions to their quirks, so that the quirks of a player may be selected
+// by the protocol version of its client, such as reported by a proxy.
+type QuirkTable []QuirkRange
+
+// Quirks returns the quirks of clients with the protocol version passed, combining the quirks of all ranges that
+// hold the version.
+func (t QuirkTable) Quirks(protocol int) Quirk {
+	var q Quirk
+	for _, r := range t {
+		if protocol >= r.MinProtocol && protocol <= r.MaxProtocol {
+			q |= r.Quirks
+		}
+	}
+	return q
+}
+
+// Degrade returns the form passed simplified according to the profile. The form is simplified after it is encoded,
+// so any form may be degraded. Composites are degraded through the elements they expand to.
+func (p Profile) Degrade(f form.Form) form.Form {
+	if p == (Profile{}) {
+		return f
+	}
+	return &degradedForm{Form: f, p: p}
+}
+
+// Profiles holds the compatibility profiles of players. The zero value of Profiles is ready to use. Profiles is safe
+// for concurrent use.
+type Profiles struct {
+	mu sync.Mutex
+	m  map[form.Submitter]Profile
+}
+
+// Set sets the profile of the submitter passed. Profiles cannot be set for submitters of which the type is not
+// comparable.
+func (p *Profiles) Set(submitter form.Submitter, profile Profile) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.m == nil {
+		p.m = make(map[form.Submitter]Profile)
+	}
+	p.m[submitter] = profile
+}
+
+// Remove removes the profile of the submitter passed, such as when the player quits.
+func (p *Profiles) Remove(submitter form.Submitter) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.m, submitter)
+}
+
+// Profile returns the profile of the submitter passed. If no profile was set, the zero Profile is returned.
+func (p *Profiles) Profile(submitter form.Submitter) Profile {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return Profile{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.m[submitter]
+}
+
+// Send sends the form passed to the submitter passed, degraded according to the profile of the submitter.
+func (p *Profiles) Send(submitter form.Submitter, f form.Form) {
+	submitter.SendForm(p.Profile(submitter).Degrade(f))
+}
+
+// degradedForm is a form degraded according to a Profile.
+type degradedForm struct {
+	form.Form
+	p Profile
+
+	// content holds the elements of the Custom form as last marshaled, used to correct the quirks of responses.
+	content []any
+}
+
+// SubmitJSON ...
+func (f *degradedForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if data != nil && f.p.Quirks != 0 && f.content != nil {
+		var err error
+		if data, err = f.p.Quirks.correct(data, f.content); err != nil {
+			return err
+		}
+	}
+	return f.Form.SubmitJSON(data, submitter)
+}
+
+// MarshalJSON ...
+func (f *degradedForm) MarshalJSON() ([]byte, error) {
+	data, err := f.Form.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("error decoding form JSON: %w", err)
+	}
+	for _, key := range [...]string{"buttons", "elements", "content"} {
+		elements, _ := m[key].([]any)
+		for _, e := range elements {
+			if element, ok := e.(map[string]any); ok {
+				f.p.degrade(element)
+			}
+		}
+	}
+	if m["type"] == "custom_form" {
+		f.content, _ = m["content"].([]any)
+	}
+	return json.Marshal(m)
+}
+
+// correct corrects the quirks in the response data passed to a Custom form with the content passed.
+func (q Quirk) correct(data []byte, content []any) ([]byte, error) {
+	var values []any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("error decoding JSON data to slice: %w", err)
+	}
+	if q&QuirkTrailingNulls != 0 {
+		for len(values) < len(content) {
+			values = append(values, nil)
+		}
+	}
+	for i, value := range values {
+		if i >= len(content) {
+			break
+		}
+		element, _ := content[i].(map[string]any)
+		if s, ok := value.(string); ok && q&QuirkStringNumbers != 0 {
+			switch element["type"] {
+			case "slider", "dropdown", "step_slider":
+				if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+					values[i] = json.Number(strings.TrimSpace(s))
+				}
+			}
+		}
+		if n, ok := values[i].(json.Number); ok && q&QuirkMisalignedSteps != 0 && element["type"] == "slider" {
+			values[i] = alignStep(n, element)
+		}
+	}
+	return json.Marshal(values)
+}
+
+// alignStep rounds the slider value passed to the nearest step of the slider with the JSON passed, without exceeding
+// the maximum of the slider.
+func alignStep(n json.Number, slider map[string]any) json.Number {
+	minValue, ok1 := slider["min"].(json.Number)
+	maxValue, ok2 := slider["max"].(json.Number)
+	stepValue, ok3 := slider["step"].(json.Number)
+	if !ok1 || !ok2 || !ok3 {
+		return n
+	}
+	v, err := n.Float64()
+	min, err1 := minValue.Float64()
+	max, err2 := maxValue.Float64()
+	step, err3 := stepValue.Float64()
+	if err != nil || err1 != nil || err2 != nil || err3 != nil || step <= 0 {
+		return n
+	}
+	return json.Number(strconv.FormatFloat(math.Min(min+math.Round((v-min)/step)*step, max), 'f', -1, 64))
+}
+
+// degrade degrades the JSON of a single element or button according to the profile.
+func (p Profile) degrade(element map[string]any) {
+	if p.NoImages {
+		delete(element, "image")
+	}
+	if p.NoTooltips {
+		delete(element, "tooltip")
+	}
+	if p.NoHeaders && (element["type"] == "header" || element["type"] == "divider") {
+		element["type"] = "label"
+	}
+	if p.MaxOptions <= 0 {
+		return
+	}
+	for _, key := range [...]string{"options", "steps"} {
+		options, ok := element[key].([]any)
+		if !ok || len(options) <= p.MaxOptions {
+			continue
+		}
+		element[key] = options[:p.MaxOptions]
+		if def, ok := element["default"].(json.Number); ok {
+			if i, err := def.Int64(); err == nil && i >= int64(p.MaxOptions) {
+				element["default"] = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package form
+
+import "strings"
+
+// ProgressBar returns a progress bar of the width passed, in characters, for use in the text of labels and buttons.
+// The part of the bar representing the progress made towards max is green, the rest is gray. If value is higher than
+// max, the bar is full.
+func ProgressBar(value, max float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := width
+	if max > 0 && value < max {
+		filled = int(value / max * float64(width))
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return "§a" + strings.Repeat("|", filled) + "§7" + strings.Repeat("|", width-filled) + "§r"
+}
@@ -0,0 +1,85 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RadioGroup represents a group of options of which exactly one must be selected. Because the client has no radio
+// buttons, it is rendered as one toggle per option, or as a dropdown if UseDropdown is true. Submitters cannot submit
+// the group with no or multiple toggles enabled.
+type RadioGroup struct {
+	// Text is the text displayed in a label over the toggles of the group. If empty, no label is added. If the group is
+	// rendered as a dropdown, the text is displayed over the dropdown instead. The text may contain Minecraft formatting
+	// codes.
+	Text string
+	// Options holds the options of the group, displayed as the text of each toggle.
+	Options []string
+	// DefaultIndex is the index of the option selected by default.
+	DefaultIndex int
+	// UseDropdown specifies if the group should be rendered as a dropdown rather than a group of toggles.
+	UseDropdown bool
+	// Submit is called with the index of the option selected by the player whenever they submit the form. If the form
+	// is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(index int)
+}
+
+// MarshalJSON ...
+func (r RadioGroup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.elements())
+}
+
+// elements ...
+func (r RadioGroup) elements() []Element {
+	if r.UseDropdown {
+		return []Element{Dropdown{Text: r.Text, Options: r.Options, DefaultIndex: r.DefaultIndex}}
+	}
+	var elements []Element
+	if r.Text != "" {
+		elements = append(elements, Label{Text: r.Text})
+	}
+	for i, option := range r.Options {
+		elements = append(elements, Toggle{Text: option, Default: i == r.DefaultIndex})
+	}
+	return elements
+}
+
+// SubmitValue ...
+func (r RadioGroup) SubmitValue(value any) error {
+	if r.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) == 0 || (!r.UseDropdown && len(values) < len(r.Options)) {
+		return fmt.Errorf("value %v is not allowed for radio group element", value)
+	}
+	if r.UseDropdown {
+		var index int
+		err := Dropdown{Options: r.Options, Submit: func(i int, _ string) { index = i }}.SubmitValue(values[0])
+		if err != nil {
+			return err
+		}
+		r.Submit(index)
+		return nil
+	}
+	values = values[len(values)-len(r.Options):]
+	selected := -1
+	for i, v := range values {
+		enabled, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("value %v is not allowed for toggle element", v)
+		}
+		if !enabled {
+			continue
+		}
+		if selected != -1 {
+			return fmt.Errorf("radio group has multiple options selected: %v and %v", selected, i)
+		}
+		selected = i
+	}
+	if selected == -1 {
+		return fmt.Errorf("radio group has no option selected")
+	}
+	r.Submit(selected)
+	return nil
+}
@@ -0,0 +1,113 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RangeInput represents a pair of elements used to select a range of values, such as a price filter or a range of
+// levels. It is rendered as two sliders, or as two text inputs if Inputs is true. Submitters cannot submit a range of
+// which the minimum is higher than the maximum.
+type RangeInput struct {
+	// Text is the text displayed in a label over the range. If empty, no label is added. The text may contain Minecraft
+	// formatting codes.
+	Text string
+	// MinText and MaxText are the texts displayed over the elements used to select the lower and upper end of the range.
+	// If left empty, 'Minimum' and 'Maximum' are displayed.
+	MinText, MaxText string
+	// Min and Max are used to specify the minimum and maximum values that may be selected for either end of the
+	// range.
+	Min, Max float64
+	// StepSize is the size that one step of the sliders takes up. It is not used if Inputs is true.
+	StepSize float64
+	// DefaultMin and DefaultMax are the default values filled out for the lower and upper end of the range.
+	DefaultMin, DefaultMax float64
+	// Inputs specifies if the range should be rendered as two text inputs instead of two sliders.
+	Inputs bool
+	// Submit is called with the range provided by the player whenever they submit the form. If the form is closed, this
+	// method is not called. This is always called before the Form's Submit.
+	Submit func(min, max float64)
+}
+
+// MarshalJSON ...
+func (r RangeInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.elements())
+}
+
+// elements ...
+func (r RangeInput) elements() []Element {
+	minText, maxText := r.MinText, r.MaxText
+	if minText == "" {
+		minText = "Minimum"
+	}
+	if maxText == "" {
+		maxText = "Maximum"
+	}
+	var elements []Element
+	if r.Text != "" {
+		elements = append(elements, Label{Text: r.Text})
+	}
+	if r.Inputs {
+		return append(elements,
+			Input{Text: minText, Default: formatFloat(r.DefaultMin), Placeholder: formatFloat(r.Min)},
+			Input{Text: maxText, Default: formatFloat(r.DefaultMax), Placeholder: formatFloat(r.Max)},
+		)
+	}
+	return append(elements,
+		Slider{Text: minText, Min: r.Min, Max: r.Max, StepSize: r.StepSize, Default: r.DefaultMin},
+		Slider{Text: maxText, Min: r.Min, Max: r.Max, StepSize: r.StepSize, Default: r.DefaultMax},
+	)
+}
+
+// SubmitValue ...
+func (r RangeInput) SubmitValue(value any) error {
+	if r.Submit == nil {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok || len(values) < 2 {
+		return fmt.Errorf("value %v is not allowed for range element", value)
+	}
+	min, err := parseNumber(values[len(values)-2])
+	if err != nil {
+		return fmt.Errorf("range minimum: %w", err)
+	}
+	max, err := parseNumber(values[len(values)-1])
+	if err != nil {
+		return fmt.Errorf("range maximum: %w", err)
+	}
+	if min < r.Min || max > r.Max {
+		return fmt.Errorf("range %v-%v is out of range %v-%v", min, max, r.Min, r.Max)
+	} else if min > max {
+		return fmt.Errorf("range minimum %v is higher than range maximum %v", min, max)
+	}
+	r.Submit(min, max)
+	return nil
+}
+
+// parseNumber parses a number submitted for a slider or text input element into a float64. NaN and infinite values
+// are rejected.
+func parseNumber(value any) (float64, error) {
+	var f float64
+	var err error
+	switch v := value.(type) {
+	case json.Number:
+		f, err = v.Float64()
+	case string:
+		f, err = strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("value %v is not a number", value)
+	}
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("value %v is not a number", value)
+	}
+	return f, nil
+}
+
+// formatFloat formats a float64 as text in its shortest representation.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,184 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"strings"
+)
+
+// Recipe is a recipe displayed in a RecipeBrowser.
+type Recipe struct {
+	// Name is the name of the recipe, usually the name of the item it produces. It may contain Minecraft formatting
+	// codes.
+	Name string
+	// Category is the category of the recipe, such as 'Tools' or 'Food'. Recipes without category are only listed
+	// under 'All recipes'.
+	Category string
+	// Image is the path or URL to the image displayed on the button of the recipe, such as one returned by Texture.
+	// It may be empty.
+	Image string
+	// Ingredients holds the ingredients needed to craft the recipe.
+	Ingredients []Ingredient
+	// Output is the amount of items produced by the recipe. If zero, one item is produced.
+	Output int
+}
+
+// Ingredient is an ingredient of a Recipe.
+type Ingredient struct {
+	// Name is the name of the ingredient. It may contain Minecraft formatting codes.
+	Name string
+	// Count is the amount of the ingredient needed.
+	Count int
+}
+
+// Crafter checks if players can craft recipes and crafts them, for example by checking and changing their inventory.
+type Crafter interface {
+	// CanCraft checks if the player can craft the recipe passed.
+	CanCraft(submitter form.Submitter, r Recipe) bool
+	// Craft crafts the recipe passed for the player. It should check again if the player can craft the recipe, and
+	// return an error if not, which is displayed to the player.
+	Craft(submitter form.Submitter, r Recipe) error
+}
+
+// RecipeBrowser represents a series of menus used to browse recipes by category or by searching them. Recipes are
+// listed in pages, and every recipe has a detail view listing its ingredients, with a button to craft it if the player
+// can craft it.
+type RecipeBrowser struct {
+	// Title is the title of the forms of the browser.
+	Title string
+	// Recipes holds the recipes that may be browsed.
+	Recipes []Recipe
+	// PageSize is the amount of recipes listed per page. If zero, 10 recipes are listed per page.
+	PageSize int
+	// Crafter is used to check if a recipe can be crafted and to craft it. If nil, recipes cannot be crafted.
+	Crafter Crafter
+}
+
+// Send sends the category menu of the browser to the submitter passed.
+func (b RecipeBrowser) Send(submitter form.Submitter) {
+	submitter.SendForm(b.categories(submitter))
+}
+
+// categories returns the menu listing the categories of the recipes.
+func (b RecipeBrowser) categories(submitter form.Submitter) *Menu {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, r := range b.Recipes {
+		if r.Category != "" && !seen[r.Category] {
+			seen[r.Category] = true
+			categories = append(categories, r.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	m := NewMenu(b.Title, "Select a category or search for a recipe.")
+	m.AddButtons(Button{Text: "Search", Image: TextureSearch, Submit: func() {
+		submitter.SendForm(b.search(submitter))
+	}})
+	m.AddButtons(Button{Text: "All recipes", Submit: func() {
+		submitter.SendForm(b.list(submitter, "All recipes", b.Recipes, 0))
+	}})
+	for _, category := range categories {
+		category := category
+		m.AddButtons(Button{Text: category, Submit: func() {
+			var recipes []Recipe
+			for _, r := range b.Recipes {
+				if r.Category == category {
+					recipes = append(recipes, r)
+				}
+			}
+			submitter.SendForm(b.list(submitter, category, recipes, 0))
+		}})
+	}
+	return m
+}
+
+// search returns the Custom form used to search recipes by name.
+func (b RecipeBrowser) search(submitter form.Submitter) *Custom {
+	var query string
+	return &Custom{
+		Title: b.Title,
+		Elements: []Element{Input{Text: "Search", Placeholder: "Recipe name", Submit: func(text string) {
+			query = text
+		}}},
+		Submit: func(closed bool, _ []any) {
+			if closed {
+				submitter.SendForm(b.categories(submitter))
+				return
+			}
+			query = strings.ToLower(strings.TrimSpace(query))
+			var recipes []Recipe
+			for _, r := range b.Recipes {
+				if strings.Contains(strings.ToLower(r.Name), query) {
+					recipes = append(recipes, r)
+				}
+			}
+			submitter.SendForm(b.list(submitter, fmt.Sprintf("Results for '%v'", query), recipes, 0))
+		},
+	}
+}
+
+// list returns the menu listing the page passed of the recipes passed.
+func (b RecipeBrowser) list(submitter form.Submitter, title string, recipes []Recipe, page int) *Menu {
+	size := b.PageSize
+	if size <= 0 {
+		size = 10
+	}
+	start, end, pages := pageBounds(len(recipes), page, size)
+	content := fmt.Sprintf("%v (page %v/%v)", title, start/size+1, pages)
+	if len(recipes) == 0 {
+		content = title + "\n\nNo recipes found."
+	}
+	m := NewMenu(b.Title, content)
+	for _, r := range recipes[start:end] {
+		r := r
+		m.AddButtons(Button{Text: r.Name, Image: r.Image, Submit: func() {
+			submitter.SendForm(b.detail(submitter, r, func() {
+				submitter.SendForm(b.list(submitter, title, recipes, page))
+			}))
+		}})
+	}
+	if start > 0 {
+		m.AddButtons(Button{Text: "Previous page", Submit: func() {
+			submitter.SendForm(b.list(submitter, title, recipes, page-1))
+		}})
+	}
+	if end < len(recipes) {
+		m.AddButtons(Button{Text: "Next page", Submit: func() {
+			submitter.SendForm(b.list(submitter, title, recipes, page+1))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		submitter.SendForm(b.categories(submitter))
+	}})
+	return m
+}
+
+// detail returns the menu displaying the recipe passed. back is called when the player presses the back button.
+func (b RecipeBrowser) detail(submitter form.Submitter, r Recipe, back func()) *Menu {
+	output := r.Output
+	if output <= 0 {
+		output = 1
+	}
+	m := NewMenu(b.Title, fmt.Sprintf("%v§r x%v", r.Name, output))
+	m.Elements = append(m.Elements, Header{Text: "Ingredients"})
+	for _, ingredient := range r.Ingredients {
+		m.Elements = append(m.Elements, Label{Text: fmt.Sprintf("%vx %v", ingredient.Count, ingredient.Name)})
+	}
+	if b.Crafter != nil {
+		if b.Crafter.CanCraft(submitter, r) {
+			m.AddButtons(Button{Text: "Craft", Image: TextureCraftingTable, Submit: func() {
+				if err := b.Crafter.Craft(submitter, r); err != nil {
+					d := b.detail(submitter, r, back)
+					d.Content += "\n\n§c" + err.Error()
+					submitter.SendForm(d)
+				}
+			}})
+		} else {
+			m.Elements = append(m.Elements, Label{Text: "§cYou do not have the ingredients to craft this recipe."})
+		}
+	}
+	m.AddButtons(Button{Text: "Back", Submit: back})
+	return m
+}
@@ -0,0 +1,232 @@
+package form
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReferralStore stores the referral codes of players and the referrals made with them. Players are identified by a
+// key, such as their UUID. Implementations must be safe for concurrent use.
+type ReferralStore interface {
+	// Code returns the referral code of the player with the key passed. If the player has no code, false is returned.
+	Code(key string) (string, bool)
+	// SetCode sets the referral code of the player with the key passed. It returns an error if the code is already in
+	// use by another player.
+	SetCode(key, code string) error
+	// Owner returns the key of the player owning the code passed. If no player owns the code, false is returned.
+	Owner(code string) (string, bool)
+	// Redeem records that the player with the key passed was referred using the code passed. It returns an error if
+	// the player already redeemed a code.
+	Redeem(key, code string) error
+	// Redeemed returns the code redeemed by the player with the key passed. If the player did not redeem a code, false
+	// is returned.
+	Redeemed(key string) (string, bool)
+	// Referrals returns the keys of the players referred by the player with the key passed of which the reward was not
+	// yet claimed.
+	Referrals(key string) []string
+	// MarkClaimed marks the reward for referring the player with the key referred as claimed by the player with the
+	// key passed. It returns false if the reward was already claimed, so that rewards are never granted twice.
+	MarkClaimed(key, referred string) bool
+}
+
+// Referrals represents the menus used for referral or invite codes: Players may enter the code of the player that
+// referred them, view their own code with instructions to share it, and claim the rewards for the players they
+// referred.
+type Referrals struct {
+	// Title is the title of the forms.
+	Title string
+	// Store stores the referral codes and referrals.
+	Store ReferralStore
+	// Format is the format that codes entered must match. If nil, codes must consist of 4 to 16 letters and digits.
+	Format *regexp.Regexp
+	// CodeLength is the length of the codes generated. If zero, codes of 6 characters are generated.
+	CodeLength int
+	// Share holds instructions on how to share the code, displayed with the code of the player. The '{code}'
+	// placeholder is replaced with the code.
+	Share string
+	// MaxAttempts is the maximum amount of codes a player may enter within AttemptWindow. If zero, players may enter
+	// any amount of codes.
+	MaxAttempts int
+	// AttemptWindow is the window in which at most MaxAttempts codes may be entered. If zero, a window of an hour is
+	// used.
+	AttemptWindow time.Duration
+	// Referred is called when the player with the key passed redeemed the code of the player with the key owner. It
+	// may be used to reward the referred player. Referred may be nil.
+	Referred func(submitter form.Submitter, key, owner string)
+	// Reward is called for every referral of which the player with the key passed claims the reward. It is called at
+	// most once per referral.
+	Reward func(submitter form.Submitter, key, referred string)
+
+	mu       sync.Mutex
+	attempts map[string][]time.Time
+}
+
+// defaultReferralFormat is the format of codes used if Format is nil.
+var defaultReferralFormat = regexp.MustCompile(`^[A-Z0-9]{4,16}$`)
+
+// referralAlphabet holds the characters used in generated codes. Characters that are easily confused, such as O and
+// 0, are left out.
+const referralAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Send sends the referral menu to the submitter passed, which is the player with the key passed.
+func (r *Referrals) Send(submitter form.Submitter, key string) {
+	submitter.SendForm(r.menu(submitter, key, ""))
+}
+
+// menu returns the referral menu, with the message passed displayed in the content.
+func (r *Referrals) menu(submitter form.Submitter, key, msg string) *Menu {
+	pending := r.Store.Referrals(key)
+	content := strings.TrimSpace("Invite your friends and earn rewards.\n\n" + msg)
+	m := NewMenu(r.Title, content)
+	if _, ok := r.Store.Redeemed(key); !ok {
+		m.AddButtons(Button{Text: "Enter a referral code", Submit: func() {
+			submitter.SendForm(r.enter(submitter, key))
+		}})
+	}
+	m.AddButtons(
+		Button{Text: "Your referral code", Submit: func() {
+			submitter.SendForm(r.code(submitter, key))
+		}},
+		Button{Text: fmt.Sprintf("Claim rewards (%v)", len(pending)), Submit: func() {
+			r.claim(submitter, key)
+		}},
+	)
+	return m
+}
+
+// enter returns the Custom form used to enter the referral code of another player.
+func (r *Referrals) enter(submitter form.Submitter, key string) *Custom {
+	var code string
+	format := r.Format
+	if format == nil {
+		format = defaultReferralFormat
+	}
+	return &Custom{
+		Title: r.Title,
+		Elements: []Element{Input{Text: "Referral code", Placeholder: "ABC123", Submit: func(s string) {
+			code = strings.ToUpper(strings.TrimSpace(s))
+		}}},
+		Submit: func(closed bool, _ []any) {
+			if closed {
+				r.Send(submitter, key)
+				return
+			}
+			if !r.attempt(key) {
+				submitter.SendForm(r.menu(submitter, key, "§cYou entered too many codes, please try again later.§r"))
+				return
+			}
+			if !format.MatchString(code) {
+				submitter.SendForm(r.menu(submitter, key, fmt.Sprintf("§c%q is not a valid code.§r", code)))
+				return
+			}
+			owner, ok := r.Store.Owner(code)
+			if !ok || owner == key {
+				submitter.SendForm(r.menu(submitter, key, fmt.Sprintf("§cCode %v does not exist.§r", code)))
+				return
+			}
+			if err := r.Store.Redeem(key, code); err != nil {
+				submitter.SendForm(r.menu(submitter, key, "§c"+err.Error()+"§r"))
+				return
+			}
+			if r.Referred != nil {
+				r.Referred(submitter, key, owner)
+			}
+			submitter.SendForm(r.menu(submitter, key, fmt.Sprintf("§aCode %v redeemed.§r", code)))
+		},
+	}
+}
+
+// attempt registers an attempt to enter a code by the player with the key passed. It returns false if the player
+// exceeded the maximum amount of attempts.
+func (r *Referrals) attempt(key string) bool {
+	if r.MaxAttempts <= 0 {
+		return true
+	}
+	window := r.AttemptWindow
+	if window <= 0 {
+		window = time.Hour
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.attempts == nil {
+		r.attempts = make(map[string][]time.Time)
+	}
+	now := time.Now()
+	attempts := r.attempts[key][:0]
+	for _, t := range r.attempts[key] {
+		if now.Sub(t) < window {
+			attempts = append(attempts, t)
+		}
+	}
+	if len(attempts) >= r.MaxAttempts {
+		r.attempts[key] = attempts
+		return false
+	}
+	r.attempts[key] = append(attempts, now)
+	return true
+}
+
+// code returns the menu displaying the referral code of the player with the key passed. If the player has no code
+// yet, a code is generated.
+func (r *Referrals) code(submitter form.Submitter, key string) *Menu {
+	code, ok := r.Store.Code(key)
+	if !ok {
+		var err error
+		if code, err = r.generate(key); err != nil {
+			return r.menu(submitter, key, "§cYour code could not be generated: "+err.Error()+"§r")
+		}
+	}
+	content := fmt.Sprintf("Your referral code is §l%v§r.", code)
+	if r.Share != "" {
+		content += "\n\n" + strings.ReplaceAll(r.Share, "{code}", code)
+	}
+	return NewMenu(r.Title, content, Button{Text: "Back", Submit: func() {
+		r.Send(submitter, key)
+	}})
+}
+
+// generate generates a new referral code for the player with the key passed and stores it.
+func (r *Referrals) generate(key string) (string, error) {
+	n := r.CodeLength
+	if n <= 0 {
+		n = 6
+	}
+	var err error
+	// Retry a few times in case the code generated is already in use.
+	for i := 0; i < 5; i++ {
+		b := make([]byte, n)
+		for j := range b {
+			k, err := rand.Int(rand.Reader, big.NewInt(int64(len(referralAlphabet))))
+			if err != nil {
+				return "", err
+			}
+			b[j] = referralAlphabet[k.Int64()]
+		}
+		if err = r.Store.SetCode(key, string(b)); err == nil {
+			return string(b), nil
+		}
+	}
+	return "", err
+}
+
+// claim claims the rewards for all referrals of the player with the key passed.
+func (r *Referrals) claim(submitter form.Submitter, key string) {
+	var n int
+	for _, referred := range r.Store.Referrals(key) {
+		if !r.Store.MarkClaimed(key, referred) {
+			// The reward was already claimed, for example from another form that was open at the same time.
+			continue
+		}
+		n++
+		if r.Reward != nil {
+			r.Reward(submitter, key, referred)
+		}
+	}
+	submitter.SendForm(r.menu(submitter, key, fmt.Sprintf("§aClaimed %v rewards.§r", n)))
+}
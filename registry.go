@@ -0,0 +1,276 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry holds forms by an ID, so that they may be defined in one place and looked up where they are sent. The
+// zero value of a Registry is empty and ready to use. A Registry is safe for concurrent use.
+type Registry struct {
+	// Variants resolves the variant of a form sent to a submitter through Send. If nil, or if no form was registered
+	// for the variant resolved, the form registered using Register is sent.
+	Variants VariantResolver
+	// Strict specifies if forms registered should be checked using Check. If a form fails the check, it is not
+	// registered and Register returns an error.
+	Strict bool
+
+	mu       sync.RWMutex
+	forms    map[string]form.Form
+	variants map[string]map[string]form.Form
+	// loaded holds the IDs of the forms registered using Load, which have no Submit functions set.
+	loaded map[string]struct{}
+}
+
+// VariantResolver resolves which variant of a form should be sent to a submitter, such as the name of the world the
+// submitter is in or its game mode. Variants of a form are registered using Registry.RegisterVariant.
+type VariantResolver interface {
+	// Variant returns the variant of forms sent to the submitter passed.
+	Variant(submitter form.Submitter) string
+}
+
+// VariantFunc is a function that implements VariantResolver.
+type VariantFunc func(submitter form.Submitter) string
+
+// Variant ...
+func (f VariantFunc) Variant(submitter form.Submitter) string {
+	return f(submitter)
+}
+
+// Register registers a form with the ID passed. If a form with the same ID was already registered, it is replaced.
+// Register only returns an error if the Registry is Strict and the form does not pass Check.
+func (r *Registry) Register(id string, f form.Form) error {
+	if r.Strict {
+		if err := Check(f); err != nil {
+			return fmt.Errorf("form %v: %w", id, err)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.forms == nil {
+		r.forms = make(map[string]form.Form)
+	}
+	r.forms[id] = f
+	delete(r.loaded, id)
+	return nil
+}
+
+// Form looks up the form registered with the ID passed. If no form was registered with the ID, false is returned.
+func (r *Registry) Form(id string) (form.Form, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.forms[id]
+	return f, ok
+}
+
+// RegisterVariant registers a form as variant of the form with the ID passed. It is sent through Send instead of the
+// form registered using Register to submitters for which the Variants of the registry resolve to the variant passed.
+// RegisterVariant only returns an error if the Registry is Strict and the form does not pass Check.
+func (r *Registry) RegisterVariant(id, variant string, f form.Form) error {
+	if r.Strict {
+		if err := Check(f); err != nil {
+			return fmt.Errorf("form %v (variant %v): %w", id, variant, err)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.variants == nil {
+		r.variants = make(map[string]map[string]form.Form)
+	}
+	if r.variants[id] == nil {
+		r.variants[id] = make(map[string]form.Form)
+	}
+	r.variants[id][variant] = f
+	return nil
+}
+
+// Resolve looks up the form with the ID passed that should be sent to the submitter passed, taking into account the
+// variants registered for the form. If no form was registered with the ID, false is returned.
+func (r *Registry) Resolve(id string, submitter form.Submitter) (form.Form, bool) {
+	if r.Variants != nil {
+		variant := r.Variants.Variant(submitter)
+		r.mu.RLock()
+		f, ok := r.variants[id][variant]
+		r.mu.RUnlock()
+		if ok {
+			return f, true
+		}
+	}
+	return r.Form(id)
+}
+
+// Send sends the form with the ID passed to the submitter, resolving the variant of the form using Resolve. If no
+// form was registered with the ID, nothing is sent and false is returned.
+func (r *Registry) Send(submitter form.Submitter, id string) bool {
+	f, ok := r.Resolve(id, submitter)
+	if ok {
+		submitter.SendForm(f)
+	}
+	return ok
+}
+
+// IDs returns the IDs of all forms in the registry, sorted alphabetically.
+func (r *Registry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.forms))
+	for id := range r.forms {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Load reads a JSON object from the reader passed that maps IDs to form definitions in the format accepted by Parse,
+// and registers every form in it. If one of the forms cannot be parsed, none of the forms are registered.
+func (r *Registry) Load(reader io.Reader) error {
+	var definitions map[string]json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&definitions); err != nil {
+		return fmt.Errorf("error decoding form definitions: %w", err)
+	}
+	forms := make(map[string]form.Form, len(definitions))
+	for id, data := range definitions {
+		f, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("error parsing form %v: %w", id, err)
+		}
+		forms[id] = f
+	}
+	for id, f := range forms {
+		// Forms parsed never have any Submit functions set, so they are registered without being checked.
+		r.mu.Lock()
+		if r.forms == nil {
+			r.forms = make(map[string]form.Form)
+		}
+		if r.loaded == nil {
+			r.loaded = make(map[string]struct{})
+		}
+		r.forms[id] = f
+		r.loaded[id] = struct{}{}
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// Export writes all forms in the registry to the writer passed as a JSON object that maps the IDs of the forms to
+// their definitions, in the format read by Load. Every form is exported using Export, so variants are not exported.
+func (r *Registry) Export(w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	definitions := make(map[string]json.RawMessage, len(r.forms))
+	for id, f := range r.forms {
+		data, err := Export(f)
+		if err != nil {
+			return fmt.Errorf("error exporting form %v: %w", id, err)
+		}
+		definitions[id] = data
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(definitions); err != nil {
+		return fmt.Errorf("error encoding form definitions: %w", err)
+	}
+	return nil
+}
+
+// RegistryProblem is a problem with a form in a Registry, as found by Registry.Lint.
+type RegistryProblem struct {
+	// ID is the ID of the form.
+	ID string
+	// Variant is the variant of the form, or an empty string if the problem is with the form registered using
+	// Register.
+	Variant string
+	// Message describes the problem.
+	Message string
+}
+
+// String ...
+func (p RegistryProblem) String() string {
+	if p.Variant != "" {
+		return fmt.Sprintf("form %v (variant %v): %v", p.ID, p.Variant, p.Message)
+	}
+	return fmt.Sprintf("form %v: %v", p.ID, p.Message)
+}
+
+// RegistryReport is a consolidated report of the problems with all forms in a Registry, as returned by Registry.Lint.
+type RegistryReport struct {
+	// Errors holds the problems that break forms, such as forms that fail to encode or do not pass Check.
+	Errors []RegistryProblem
+	// Warnings holds the likely mistakes in forms found by Lint.
+	Warnings []RegistryProblem
+}
+
+// Err returns an error listing all Errors of the report, and all Warnings if warnings is true. If there are no such
+// problems, Err returns nil.
+func (r RegistryReport) Err(warnings bool) error {
+	problems := r.Errors
+	if warnings {
+		problems = append(append([]RegistryProblem(nil), r.Errors...), r.Warnings...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	lines := make([]string, len(problems))
+	for i, p := range problems {
+		lines[i] = "\t" + p.String()
+	}
+	return fmt.Errorf("%v problems found in forms:\n%v", len(problems), strings.Join(lines, "\n"))
+}
+
+// Lint checks every form and variant in the registry using Check and Lint, and checks that they can be encoded, and
+// returns a consolidated report of all problems found. Forms registered using Load are not checked using Check, as
+// they never have any Submit functions set. It is designed to be called when the server starts or from a
+// program run by go:generate, so that broken forms are found before they reach players:
+//
+//	if err := forms.Lint().Err(false); err != nil {
+//		log.Fatalln(err)
+//	}
+func (r *Registry) Lint() RegistryReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var report RegistryReport
+	lint := func(id, variant string, f form.Form, loaded bool) {
+		problem := func(message string) RegistryProblem {
+			return RegistryProblem{ID: id, Variant: variant, Message: message}
+		}
+		if err := Check(f); err != nil && !loaded {
+			report.Errors = append(report.Errors, problem(err.Error()))
+		}
+		if _, err := f.MarshalJSON(); err != nil {
+			report.Errors = append(report.Errors, problem("cannot encode form: "+err.Error()))
+		}
+		for _, w := range Lint(f) {
+			report.Warnings = append(report.Warnings, problem(w.String()))
+		}
+	}
+	ids := make([]string, 0, len(r.forms))
+	for id := range r.forms {
+		ids = append(ids, id)
+	}
+	for id := range r.variants {
+		if _, ok := r.forms[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if f, ok := r.forms[id]; ok {
+			_, loaded := r.loaded[id]
+			lint(id, "", f, loaded)
+		}
+		variants := make([]string, 0, len(r.variants[id]))
+		for variant := range r.variants[id] {
+			variants = append(variants, variant)
+		}
+		sort.Strings(variants)
+		for _, variant := range variants {
+			lint(id, variant, r.variants[id][variant], false)
+		}
+	}
+	return report
+}
@@ -0,0 +1,190 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+	"time"
+)
+
+// Reminder is a pending action of a player, such as an unclaimed reward or unread mail.
+type Reminder struct {
+	// ID is the ID of the reminder, used to remember if the player snoozed or dismissed it. Reminders that are
+	// provided again with the same ID are considered the same reminder.
+	ID string
+	// Text is the text of the button of the reminder. The text may contain Minecraft formatting codes.
+	Text string
+	// Image is the path or URL to the image displayed on the button of the reminder. It may be empty.
+	Image string
+	// Open is called when the player chooses to act on the reminder, such as to open the mailbox. Open may be nil.
+	Open func(submitter form.Submitter)
+}
+
+// ReminderProvider provides the pending actions of the player with the key passed.
+type ReminderProvider func(key string) []Reminder
+
+// ReminderStore persists the reminders snoozed or dismissed by players.
+type ReminderStore interface {
+	// Snoozed returns the time until which the reminder with the ID passed was snoozed by the player with the key
+	// passed. If the reminder was not snoozed, false is returned.
+	Snoozed(key, id string) (time.Time, bool)
+	// Snooze snoozes the reminder with the ID passed for the player with the key passed until the time passed.
+	Snooze(key, id string, until time.Time)
+}
+
+// MemoryReminderStore is a ReminderStore that keeps snoozed reminders in memory. The zero value of a
+// MemoryReminderStore is ready to use. A MemoryReminderStore is safe for concurrent use.
+type MemoryReminderStore struct {
+	mu sync.Mutex
+	m  map[[2]string]time.Time
+}
+
+// Snoozed ...
+func (s *MemoryReminderStore) Snoozed(key, id string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.m[[2]string{key, id}]
+	return until, ok
+}
+
+// Snooze ...
+func (s *MemoryReminderStore) Snooze(key, id string, until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[[2]string]time.Time)
+	}
+	s.m[[2]string{key, id}] = until
+}
+
+// dismissed is the time until which dismissed reminders are snoozed.
+var dismissed = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Reminders sends players a single menu with all their pending actions, obtained from a set of providers. Players may
+// act on, snooze or dismiss each reminder. Reminders should be sent when a player joins, and may be sent periodically
+// using Start.
+type Reminders struct {
+	// Title is the title of the reminder menu. If empty, 'Reminders' is used.
+	Title string
+	// Providers holds the providers of the pending actions of players.
+	Providers []ReminderProvider
+	// SnoozeDuration is the time for which a reminder is snoozed. If zero, reminders are snoozed for an hour.
+	SnoozeDuration time.Duration
+	// Store is the store in which snoozed and dismissed reminders are kept. If nil, reminders cannot be snoozed or
+	// dismissed.
+	Store ReminderStore
+}
+
+// Pending returns the pending reminders of the player with the key passed that were not snoozed or dismissed.
+func (r *Reminders) Pending(key string) []Reminder {
+	var pending []Reminder
+	now := time.Now()
+	for _, provider := range r.Providers {
+		for _, reminder := range provider(key) {
+			if r.Store != nil {
+				if until, ok := r.Store.Snoozed(key, reminder.ID); ok && now.Before(until) {
+					continue
+				}
+			}
+			pending = append(pending, reminder)
+		}
+	}
+	return pending
+}
+
+// Send sends the reminder menu to the submitter passed, which is the player with the key passed, such as its UUID.
+// If the player has no pending reminders, nothing is sent and false is returned.
+func (r *Reminders) Send(submitter form.Submitter, key string) bool {
+	pending := r.Pending(key)
+	if len(pending) == 0 {
+		return false
+	}
+	submitter.SendForm(r.menu(submitter, key, pending))
+	return true
+}
+
+// Start sends the reminder menu to the players returned by the function passed every interval, until the function
+// returned is called. The players are returned as a map of their keys to their submitters.
+func (r *Reminders) Start(interval time.Duration, players func() map[string]form.Submitter) (stop func()) {
+	ticker := time.NewTicker(interval)
+	c := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				for key, submitter := range players() {
+					r.Send(submitter, key)
+				}
+			case <-c:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(c) })
+	}
+}
+
+// title returns the title of the reminder menu.
+func (r *Reminders) title() string {
+	if r.Title == "" {
+		return "Reminders"
+	}
+	return r.Title
+}
+
+// snoozeDuration returns the time for which a reminder is snoozed.
+func (r *Reminders) snoozeDuration() time.Duration {
+	if r.SnoozeDuration <= 0 {
+		return time.Hour
+	}
+	return r.SnoozeDuration
+}
+
+// menu returns the reminder menu for the pending reminders passed.
+func (r *Reminders) menu(submitter form.Submitter, key string, pending []Reminder) *Menu {
+	m := NewMenu(r.title(), fmt.Sprintf("You have %v pending actions.", len(pending)))
+	for _, reminder := range pending {
+		reminder := reminder
+		m.AddButtons(Button{Text: reminder.Text, Image: reminder.Image, Submit: func() {
+			submitter.SendForm(r.reminder(submitter, key, reminder))
+		}})
+	}
+	if r.Store != nil {
+		m.AddButtons(Button{Text: "Snooze all", Submit: func() {
+			until := time.Now().Add(r.snoozeDuration())
+			for _, reminder := range pending {
+				r.Store.Snooze(key, reminder.ID, until)
+			}
+		}})
+	}
+	return m
+}
+
+// reminder returns the menu of a single reminder, used to act on, snooze or dismiss it.
+func (r *Reminders) reminder(submitter form.Submitter, key string, reminder Reminder) *Menu {
+	m := NewMenu(r.title(), reminder.Text)
+	if reminder.Open != nil {
+		m.AddButtons(Button{Text: "Open", Submit: func() {
+			reminder.Open(submitter)
+		}})
+	}
+	if r.Store != nil {
+		m.AddButtons(
+			Button{Text: "Snooze", Submit: func() {
+				r.Store.Snooze(key, reminder.ID, time.Now().Add(r.snoozeDuration()))
+				r.Send(submitter, key)
+			}},
+			Button{Text: "Dismiss", Submit: func() {
+				r.Store.Snooze(key, reminder.ID, dismissed)
+				r.Send(submitter, key)
+			}},
+		)
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		r.Send(submitter, key)
+	}})
+	return m
+}
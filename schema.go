@@ -0,0 +1,265 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FieldType is the type of value held by a Field of a Schema.
+type FieldType int
+
+const (
+	// FieldString is a field holding a string, rendered as a text input.
+	FieldString FieldType = iota
+	// FieldBool is a field holding a bool, rendered as a toggle.
+	FieldBool
+	// FieldNumber is a field holding a float64. It is rendered as a slider if the field has a range and a step size,
+	// or as a text input otherwise.
+	FieldNumber
+	// FieldInteger is a field holding an int. It is rendered as a slider if the field has a range and a step size, or
+	// as a text input otherwise.
+	FieldInteger
+	// FieldEnum is a field holding one of the Options of the field as a string, rendered as a dropdown.
+	FieldEnum
+)
+
+// String ...
+func (t FieldType) String() string {
+	switch t {
+	case FieldString:
+		return "string"
+	case FieldBool:
+		return "bool"
+	case FieldNumber:
+		return "number"
+	case FieldInteger:
+		return "integer"
+	case FieldEnum:
+		return "enum"
+	}
+	return fmt.Sprintf("FieldType(%d)", int(t))
+}
+
+// Field describes a single value of a Schema, such as a property of a JSON Schema or a field of a protobuf message.
+type Field struct {
+	// Name is the name of the field. It is used as key in the result map and must be unique within a Schema.
+	Name string
+	// Text is the text displayed over the element of the field. If empty, Name is displayed.
+	Text string
+	// Type is the type of value the field holds.
+	Type FieldType
+	// Default is the default value of the field. Its type must match the type of the field: A string for FieldString
+	// and FieldEnum, a bool for FieldBool, a float64 for FieldNumber and an int for FieldInteger. Default may be nil.
+	Default any
+	// Placeholder is the placeholder of fields rendered as text input.
+	Placeholder string
+	// Required specifies if a FieldString field may not be submitted empty.
+	Required bool
+	// Nullable specifies if a field rendered as text input may be submitted empty, in which case the value of the field
+	// is nil.
+	Nullable bool
+	// Min and Max specify the range of values of a FieldNumber or FieldInteger field. If Min and Max are both zero,
+	// the value of the field is not bounded.
+	Min, Max float64
+	// StepSize is the step size of a FieldNumber or FieldInteger field. If the field has a range and a non-zero step
+	// size, it is rendered as a slider.
+	StepSize float64
+	// Options holds the options of a FieldEnum field.
+	Options []string
+}
+
+// Schema is a description of structured data, created at runtime, that may be rendered into a Custom form to edit
+// the data.
+type Schema struct {
+	// Title is the title of the form rendered.
+	Title string
+	// Fields holds the fields of the schema, in the order in which they are displayed.
+	Fields []Field
+}
+
+// Render renders the schema into a Custom form. When submitted, the values of the form are passed to the submit
+// function in a map with the name of each field as key. Every submission is passed a new map, so the form returned
+// may be sent to multiple players. Render returns an error if the schema is invalid.
+func (s Schema) Render(submit func(closed bool, values map[string]any)) (*Custom, error) {
+	c := &Custom{Title: s.Title, Submit: func(closed bool, submitted []any) {
+		if submit == nil {
+			return
+		}
+		if closed {
+			submit(true, nil)
+			return
+		}
+		values := make(map[string]any, len(s.Fields))
+		for i, f := range s.Fields {
+			values[f.Name] = f.value(submitted[i])
+		}
+		submit(false, values)
+	}}
+	names := make(map[string]struct{}, len(s.Fields))
+	for _, f := range s.Fields {
+		if _, ok := names[f.Name]; ok {
+			return nil, fmt.Errorf("schema has multiple fields with name %v", f.Name)
+		}
+		names[f.Name] = struct{}{}
+		element, err := f.element()
+		if err != nil {
+			return nil, fmt.Errorf("schema field %v: %w", f.Name, err)
+		}
+		c.AddElements(element)
+	}
+	return c, nil
+}
+
+// value returns the value of the field from the value submitted to a Custom form for the element of the field, which
+// was already accepted by the element.
+func (f Field) value(submitted any) any {
+	if f.Type == FieldEnum {
+		// Dropdowns submit the index of the option selected.
+		index, _ := submitted.(json.Number).Int64()
+		return f.Options[index]
+	}
+	return submitted
+}
+
+// element returns the Element used to render the field. The value of the element, as passed to the Submit of the form,
+// is turned into the value of the field using value.
+func (f Field) element() (Element, error) {
+	text := f.Text
+	if text == "" {
+		text = f.Name
+	}
+	bounded := f.Min != 0 || f.Max != 0
+	switch f.Type {
+	case FieldString:
+		def, ok := f.Default.(string)
+		if !ok && f.Default != nil {
+			return nil, fmt.Errorf("default %v is not a string", f.Default)
+		}
+		input := Input{Text: text, Default: def, Placeholder: f.Placeholder}
+		return parsedInput{Input: input, parse: func(s string) (any, error) {
+			if f.Nullable && s == "" {
+				return nil, nil
+			} else if f.Required && strings.TrimSpace(s) == "" {
+				return nil, fmt.Errorf("value of %v is required", f.Name)
+			}
+			return s, nil
+		}}, nil
+	case FieldBool:
+		def, ok := f.Default.(bool)
+		if !ok && f.Default != nil {
+			return nil, fmt.Errorf("default %v is not a bool", f.Default)
+		}
+		return Toggle{Text: text, Default: def}, nil
+	case FieldNumber, FieldInteger:
+		var def float64
+		switch v := f.Default.(type) {
+		case nil:
+		case float64:
+			def = v
+		case int:
+			def = float64(v)
+		default:
+			return nil, fmt.Errorf("default %v is not a number", f.Default)
+		}
+		set := func(n float64) (any, error) {
+			if bounded && (n < f.Min || n > f.Max) {
+				return nil, fmt.Errorf("value %v of %v is out of range %v-%v", n, f.Name, f.Min, f.Max)
+			}
+			if f.Type == FieldInteger {
+				if n != math.Trunc(n) {
+					return nil, fmt.Errorf("value %v of %v is not an integer", n, f.Name)
+				} else if n < math.MinInt || n >= math.MaxInt {
+					return nil, fmt.Errorf("value %v of %v is out of the range of an integer", n, f.Name)
+				}
+				return int(n), nil
+			}
+			return n, nil
+		}
+		if bounded && f.StepSize != 0 {
+			slider := Slider{Text: text, Min: f.Min, Max: f.Max, StepSize: f.StepSize, Default: def}
+			return parsedSlider{Slider: slider, parse: func(value float64) (any, error) {
+				if f.Type == FieldInteger {
+					value = math.Round(value)
+				}
+				return set(value)
+			}}, nil
+		}
+		defText := formatFloat(def)
+		if f.Nullable && f.Default == nil {
+			defText = ""
+		}
+		input := Input{Text: text, Default: defText, Placeholder: f.Placeholder}
+		return parsedInput{Input: input, parse: func(s string) (any, error) {
+			if f.Nullable && strings.TrimSpace(s) == "" {
+				return nil, nil
+			}
+			n, err := parseNumber(s)
+			if err != nil {
+				return nil, fmt.Errorf("value %v of %v is not a number", s, f.Name)
+			}
+			return set(n)
+		}}, nil
+	case FieldEnum:
+		if len(f.Options) == 0 {
+			return nil, fmt.Errorf("enum field has no options")
+		}
+		def, ok := f.Default.(string)
+		if !ok && f.Default != nil {
+			return nil, fmt.Errorf("default %v is not a string", f.Default)
+		}
+		index := 0
+		for i, option := range f.Options {
+			if option == def {
+				index = i
+			}
+		}
+		return Dropdown{Text: text, Options: f.Options, DefaultIndex: index}, nil
+	}
+	return nil, fmt.Errorf("unknown field type %v", f.Type)
+}
+
+// parsedInput is an Input of which the submitted text is parsed before it is accepted. If parsing fails, the error is
+// returned from SubmitValue. The value parsed is passed to the Submit of the form instead of the text.
+type parsedInput struct {
+	Input
+	parse func(text string) (any, error)
+}
+
+// SubmitValue ...
+func (i parsedInput) SubmitValue(value any) error {
+	_, err := i.normalize(value)
+	return err
+}
+
+// normalize ...
+func (i parsedInput) normalize(value any) (any, error) {
+	text, err := i.Input.accept(value)
+	if err != nil {
+		return nil, err
+	}
+	return i.parse(text)
+}
+
+// parsedSlider is a Slider of which the submitted value is parsed before it is accepted. If parsing fails, the error is
+// returned from SubmitValue. The value parsed is passed to the Submit of the form instead of the value submitted.
+type parsedSlider struct {
+	Slider
+	parse func(value float64) (any, error)
+}
+
+// SubmitValue ...
+func (s parsedSlider) SubmitValue(value any) error {
+	_, err := s.normalize(value)
+	return err
+}
+
+// normalize ...
+func (s parsedSlider) normalize(value any) (any, error) {
+	val, err := s.Slider.accept(value)
+	if err != nil {
+		return nil, err
+	}
+	return s.parse(val)
+}
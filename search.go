@@ -0,0 +1,114 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strings"
+)
+
+// Matcher checks if the name of an item matches the query passed. The query and name are passed in lower case and
+// without formatting codes.
+type Matcher func(query, name string) bool
+
+// MatchPrefix is a Matcher that matches names starting with the query.
+func MatchPrefix(query, name string) bool {
+	return strings.HasPrefix(name, query)
+}
+
+// MatchContains is a Matcher that matches names containing the query.
+func MatchContains(query, name string) bool {
+	return strings.Contains(name, query)
+}
+
+// MatchFuzzy is a Matcher that matches names containing all characters of the query in order, though not
+// necessarily next to each other, so that 'dmsw' matches 'diamond sword'.
+func MatchFuzzy(query, name string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(name, r)
+		if i == -1 {
+			return false
+		}
+		name = name[i+len(string(r)):]
+	}
+	return true
+}
+
+// SearchMenu represents a form used to search a list of items, such as warps, players or shops. The player is first
+// sent an input to enter a search query, after which a menu with a button for every item matching the query is sent.
+// The item of the button clicked is submitted.
+type SearchMenu[T any] struct {
+	// Title is the title of the forms.
+	Title string
+	// Items holds the items that may be searched.
+	Items []T
+	// Name returns the name of an item, which is matched against the query and displayed on its button.
+	Name func(item T) string
+	// Image returns the image displayed on the button of an item. Image may be nil.
+	Image func(item T) string
+	// Match is used to match the names of items against the query. If nil, MatchContains is used.
+	Match Matcher
+	// Submit is called with the item of the button clicked by the player.
+	Submit func(item T)
+}
+
+// MarshalJSON ...
+func (s *SearchMenu[T]) MarshalJSON() ([]byte, error) {
+	return s.custom(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (s *SearchMenu[T]) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return s.custom(submitter).SubmitJSON(data, submitter)
+}
+
+// custom returns the Custom form used to enter the search query. Submitting it sends the results to the submitter
+// passed.
+func (s *SearchMenu[T]) custom(submitter form.Submitter) *Custom {
+	var query string
+	return &Custom{
+		Title: s.Title,
+		Elements: []Element{Input{Text: "Search", Placeholder: "Leave empty to show everything", Submit: func(q string) {
+			query = q
+		}}},
+		Submit: func(closed bool, _ []any) {
+			if !closed {
+				submitter.SendForm(s.results(submitter, query))
+			}
+		},
+	}
+}
+
+// results returns the menu listing the items matching the query passed.
+func (s *SearchMenu[T]) results(submitter form.Submitter, query string) *Menu {
+	match := s.Match
+	if match == nil {
+		match = MatchContains
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	m := NewMenu(s.Title, "")
+	for _, item := range s.Items {
+		item := item
+		name := s.Name(item)
+		if query != "" && !match(query, strings.ToLower(text.Clean(name))) {
+			continue
+		}
+		var image string
+		if s.Image != nil {
+			image = s.Image(item)
+		}
+		m.AddButtons(Button{Text: name, Image: image, Submit: func() {
+			if s.Submit != nil {
+				s.Submit(item)
+			}
+		}})
+	}
+	m.Content = fmt.Sprintf("%v results found.", len(m.Buttons))
+	if query != "" {
+		m.Content = fmt.Sprintf("%v results found for '%v'.", len(m.Buttons), query)
+	}
+	m.AddButtons(Button{Text: "Search again", Image: TextureSearch, Submit: func() {
+		submitter.SendForm(s)
+	}})
+	return m
+}
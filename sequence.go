@@ -0,0 +1,120 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strconv"
+)
+
+// ModalSequence represents a series of modals that are shown to a player one after another, such as a number of
+// confirmations that must all be answered. Closing any of the modals aborts the sequence. A ModalSequence may only
+// be sent to a single player at a time, and must be sent using Send for the Timeout of its modals to start.
+type ModalSequence struct {
+	// Modals holds the modals of the sequence, in the order in which they are shown. The Submit functions of the modals
+	// and their buttons are called as usual when a modal is answered.
+	Modals []Modal
+	// Submit is called once the last modal is answered, or once any of the modals is closed. The choices slice holds,
+	// for every modal answered, true if its first button was clicked and false if its second button was clicked.
+	Submit func(closed bool, choices []bool)
+
+	choices []bool
+}
+
+// Sequence returns a ModalSequence that shows the modals passed in order. The Submit function of the sequence may be
+// set on the ModalSequence returned.
+func Sequence(modals ...Modal) *ModalSequence {
+	return &ModalSequence{Modals: modals}
+}
+
+// MarshalJSON ...
+func (s *ModalSequence) MarshalJSON() ([]byte, error) {
+	m := s.current()
+	return m.MarshalJSON()
+}
+
+// Send sends the modal currently shown to the submitter passed and starts its Timeout, if it has one. Once the modal
+// resolves itself, the sequence continues as if the TimeoutButton was clicked.
+func (s *ModalSequence) Send(submitter form.Submitter) {
+	m := s.current()
+	sent := &timedSequence{ModalSequence: s}
+	sent.timeout.start(m.Timeout, func() {
+		m.expire(func(first bool) error {
+			return s.submit([]byte(strconv.FormatBool(first)), submitter)
+		})
+	})
+	submitter.SendForm(sent)
+}
+
+// SubmitJSON ...
+func (s *ModalSequence) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return s.submit(data, submitter)
+}
+
+// submit submits the response passed to the modal currently shown and shows the next modal, if any. If the button
+// clicked is disabled, the sequence does not move on to the next modal.
+func (s *ModalSequence) submit(data []byte, submitter form.Submitter) error {
+	m := s.current()
+	var clicked, choice bool
+	submit1, submit2 := m.Button1.Submit, m.Button2.Submit
+	m.Button1.Submit = func() {
+		clicked, choice = true, true
+		if submit1 != nil {
+			submit1()
+		}
+	}
+	m.Button2.Submit = func() {
+		clicked = true
+		if submit2 != nil {
+			submit2()
+		}
+	}
+	if err := m.SubmitJSON(data, submitter); err != nil {
+		return err
+	}
+	if data == nil {
+		choices := s.choices
+		s.choices = nil
+		if s.Submit != nil {
+			s.Submit(true, choices)
+		}
+		return nil
+	}
+	if !clicked {
+		// The button clicked was disabled, so the sequence does not advance.
+		return nil
+	}
+	s.choices = append(s.choices, choice)
+	if len(s.choices) < len(s.Modals) {
+		s.Send(submitter)
+		return nil
+	}
+	choices := s.choices
+	s.choices = nil
+	if s.Submit != nil {
+		s.Submit(false, choices)
+	}
+	return nil
+}
+
+// current returns a copy of the modal currently shown.
+func (s *ModalSequence) current() Modal {
+	if len(s.choices) >= len(s.Modals) {
+		return Modal{}
+	}
+	return s.Modals[len(s.choices)]
+}
+
+// timedSequence is a ModalSequence of which the modal currently shown was sent using Send, and of which the Timeout
+// was started.
+type timedSequence struct {
+	*ModalSequence
+	timeout modalTimeout
+}
+
+// SubmitJSON ...
+func (s *timedSequence) SubmitJSON(data []byte, submitter form.Submitter) error {
+	if !s.timeout.resolve() {
+		// The modal already resolved itself because of its timeout.
+		return nil
+	}
+	return s.ModalSequence.submit(data, submitter)
+}
@@ -0,0 +1,49 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+)
+
+// Serializer encodes forms to, and decodes forms from, a specific encoding. Forms and elements are defined
+// independently of their encoding, so that other encodings may be supported by implementing a Serializer, should the
+// protocol ever change to a non-JSON form payload. Note that dragonfly currently always sends forms as JSON.
+type Serializer interface {
+	// Encode encodes the form passed.
+	Encode(f form.Form) ([]byte, error)
+	// Decode decodes a form previously encoded by Encode. The form returned has no Submit functions set.
+	Decode(data []byte) (form.Form, error)
+}
+
+// JSON is the Serializer that encodes forms as JSON, in the layout sent to the client.
+var JSON Serializer = JSONSerializer{}
+
+// JSONSerializer is a Serializer that encodes forms as JSON, in the layout sent to the client. It decodes forms using
+// Parse.
+type JSONSerializer struct {
+	// Indent specifies if the JSON encoded should be indented, so that it may be stored in files edited by hand.
+	Indent bool
+}
+
+// Encode ...
+func (s JSONSerializer) Encode(f form.Form) ([]byte, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding form JSON: %w", err)
+	}
+	if !s.Indent {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "    "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode ...
+func (JSONSerializer) Decode(data []byte) (form.Form, error) {
+	return Parse(data)
+}
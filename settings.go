@@ -0,0 +1,70 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"strings"
+)
+
+// ServerSettings represents the form displayed in a tab for the server in the settings of a client. The client
+// requests it with a ServerSettingsRequest packet when it opens its settings, and the server or proxy may respond to
+// it with a ServerSettingsResponse packet, as returned by Packet. It is structured like a Custom form, with an icon
+// displayed on the tab. The response of the client, sent when it closes its settings, should be submitted using
+// SubmitJSON.
+type ServerSettings struct {
+	// Title is the title of the tab in the settings.
+	Title string
+	// Icon holds a path to the image displayed on the tab. The Icon may either be a URL pointing to an image, or a path
+	// pointing to a local asset, such as 'textures/ui/gear'. It may be empty.
+	Icon string
+	// Elements is a slice of elements that can be modified by a player.
+	Elements []Element
+	// Submit is called when the player closes its settings. It is called like the Submit of a Custom form.
+	Submit func(closed bool, values []any)
+}
+
+// MarshalJSON ...
+func (s *ServerSettings) MarshalJSON() ([]byte, error) {
+	if len(s.Elements) == 0 {
+		return nil, errors.New("server settings form requires at least one element")
+	}
+	if err := ValidateImage(s.Icon); err != nil {
+		return nil, err
+	}
+	m := map[string]any{
+		"type":    "custom_form",
+		"title":   s.Title,
+		"content": s.custom().content(),
+	}
+	if s.Icon != "" {
+		iconType := "path"
+		if strings.HasPrefix(s.Icon, "http:") || strings.HasPrefix(s.Icon, "https:") {
+			iconType = "url"
+		}
+		m["icon"] = map[string]any{"type": iconType, "data": s.Icon}
+	}
+	return json.Marshal(m)
+}
+
+// SubmitJSON ...
+func (s *ServerSettings) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return s.custom().SubmitJSON(data, submitter)
+}
+
+// Packet returns the ServerSettingsResponse packet holding the form, with the form ID passed. The ModalFormResponse
+// packet that the client sends back holds the same form ID.
+func (s *ServerSettings) Packet(id uint32) (*packet.ServerSettingsResponse, error) {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding server settings: %w", err)
+	}
+	return &packet.ServerSettingsResponse{FormID: id, FormData: data}, nil
+}
+
+// custom returns the Custom form holding the elements of the settings.
+func (s *ServerSettings) custom() *Custom {
+	return &Custom{Title: s.Title, Elements: s.Elements, Submit: s.Submit}
+}
@@ -0,0 +1,117 @@
+package form
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SignedSubmission is a submission of a form signed by a Signer, such as for an audit log or a webhook. Systems that
+// receive it, such as web panels or Discord bots, can verify that it originated from the server using the key of the
+// Signer.
+type SignedSubmission struct {
+	// FormID is the ID of the form submitted.
+	FormID string `json:"form_id"`
+	// XUID is the XUID of the player that submitted the form.
+	XUID string `json:"xuid"`
+	// Values holds the values submitted, encoded as JSON.
+	Values json.RawMessage `json:"values"`
+	// Time is the Unix time in seconds at which the submission was signed.
+	Time int64 `json:"time"`
+	// Signature is the hex encoded HMAC-SHA256 of the other fields, as returned by Signer.Signature.
+	Signature string `json:"signature"`
+}
+
+// Signer signs submissions of forms using HMAC-SHA256 with a key shared with the systems receiving them.
+type Signer struct {
+	// Key is the secret key used to sign submissions.
+	Key []byte
+}
+
+// Sign signs the values passed, submitted by the player with the XUID passed for the form with the ID passed. The
+// values must be encodable as JSON.
+func (s Signer) Sign(formID, xuid string, values any) (SignedSubmission, error) {
+	if len(s.Key) == 0 {
+		return SignedSubmission{}, errors.New("signer has no key")
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return SignedSubmission{}, fmt.Errorf("error encoding values: %w", err)
+	}
+	sub := SignedSubmission{FormID: formID, XUID: xuid, Values: data, Time: time.Now().Unix()}
+	sub.Signature = s.Signature(sub)
+	return sub, nil
+}
+
+// Verify checks if the signature of the submission passed was created with the key of the Signer, and thus that the
+// submission was not forged or changed.
+func (s Signer) Verify(sub SignedSubmission) bool {
+	expected, err := hex.DecodeString(sub.Signature)
+	if err != nil || len(s.Key) == 0 {
+		return false
+	}
+	actual, _ := hex.DecodeString(s.Signature(sub))
+	return hmac.Equal(expected, actual)
+}
+
+// Signature returns the hex encoded HMAC-SHA256 of the submission passed, ignoring its Signature field. The message
+// signed is made up of the form ID, the XUID, the values and the time in decimal, in that order, with every field
+// prefixed by its length in bytes as a big endian uint64, and with the values in the compacted form of their JSON.
+// Prefixing the lengths ensures that no two different submissions produce the same message.
+func (s Signer) Signature(sub SignedSubmission) string {
+	mac := hmac.New(sha256.New, s.Key)
+	values := []byte(sub.Values)
+	if compact, err := compactJSON(values); err == nil {
+		values = compact
+	}
+	for _, field := range [][]byte{[]byte(sub.FormID), []byte(sub.XUID), values, []byte(strconv.FormatInt(sub.Time, 10))} {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		_, _ = mac.Write(length[:])
+		_, _ = mac.Write(field)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SubmitMeta returns a function that may be set as the SubmitMeta of a Custom form sent to the player with the XUID
+// passed. When the form is submitted, its values are signed with the ID passed and passed to the publish function,
+// such as to post them to a webhook. Values are keyed by the ID of their element, as set using WithID, or by the index
+// of their element if it has no ID. If the values cannot be signed, or if multiple elements have the same key, the
+// error is passed instead.
+func (s Signer) SubmitMeta(formID, xuid string,
+	publish func(sub SignedSubmission, err error)) func(bool, []ElementValue) {
+	return func(closed bool, values []ElementValue) {
+		if closed {
+			return
+		}
+		m := make(map[string]any, len(values))
+		for _, v := range values {
+			key := idOf(v.Element)
+			if key == "" {
+				key = strconv.Itoa(v.Index)
+			}
+			if _, ok := m[key]; ok {
+				publish(SignedSubmission{}, fmt.Errorf("multiple values with key %v", key))
+				return
+			}
+			m[key] = v.Value
+		}
+		publish(s.Sign(formID, xuid, m))
+	}
+}
+
+// compactJSON returns the JSON passed without insignificant whitespace.
+func compactJSON(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sync"
+)
+
+// StaffState holds the duty state of a staff member.
+type StaffState struct {
+	// Vanished specifies if the staff member is invisible to other players.
+	Vanished bool
+	// StaffMode specifies if the staff member is in staff mode, such as with moderation tools in its inventory.
+	StaffMode bool
+}
+
+// StaffStore persists the StaffState of staff members, so that it may be restored when they join. Staff members are
+// identified by a key, such as their UUID.
+type StaffStore interface {
+	// State returns the state of the staff member with the key passed. If no state was stored, false is returned.
+	State(key string) (StaffState, bool)
+	// SetState stores the state of the staff member with the key passed.
+	SetState(key string, state StaffState)
+}
+
+// MemoryStaffStore is a StaffStore that keeps states in memory. The zero value of a MemoryStaffStore is ready to use.
+// A MemoryStaffStore is safe for concurrent use.
+type MemoryStaffStore struct {
+	mu sync.Mutex
+	m  map[string]StaffState
+}
+
+// State ...
+func (s *MemoryStaffStore) State(key string) (StaffState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.m[key]
+	return state, ok
+}
+
+// SetState ...
+func (s *MemoryStaffStore) SetState(key string, state StaffState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[string]StaffState)
+	}
+	s.m[key] = state
+}
+
+// StaffPanel represents the panel used by staff members to go on or off duty: It holds toggles for vanish and staff
+// mode, of which the state is persisted in a StaffStore, and a number of quick actions. Quick actions that require a
+// permission may be hidden using the Visible function of their button.
+type StaffPanel struct {
+	// Title is the title of the forms. If empty, 'Staff panel' is used.
+	Title string
+	// Store is the store in which the state of staff members is persisted.
+	Store StaffStore
+	// Apply is called with the state of a staff member when it is changed in the panel or restored using Restore, and
+	// should apply the state, such as by hiding the player from others.
+	Apply func(submitter form.Submitter, state StaffState)
+	// Actions holds the quick actions displayed in the panel, such as teleporting to a random player or opening the
+	// report queue. Actions that are not Visible to a staff member are not displayed to it.
+	Actions []Button
+}
+
+// Send sends the panel to the submitter passed, which is the staff member with the key passed.
+func (p StaffPanel) Send(submitter form.Submitter, key string) {
+	state, _ := p.Store.State(key)
+	m := NewMenu(p.title(), p.content(state), Button{Text: "Duty settings", Image: TextureSettings, Submit: func() {
+		submitter.SendForm(p.settings(submitter, key))
+	}})
+	m.AddButtons(p.Actions...)
+	submitter.SendForm(m.For(submitter))
+}
+
+// Restore applies the state stored for the staff member with the key passed. It should be called when a staff member
+// joins, so that it remains vanished or in staff mode across sessions. If no state was stored, Restore does nothing
+// and returns false.
+func (p StaffPanel) Restore(submitter form.Submitter, key string) bool {
+	state, ok := p.Store.State(key)
+	if ok && p.Apply != nil {
+		p.Apply(submitter, state)
+	}
+	return ok
+}
+
+// title returns the title of the forms.
+func (p StaffPanel) title() string {
+	if p.Title == "" {
+		return "Staff panel"
+	}
+	return p.Title
+}
+
+// content returns the content of the panel, displaying the state passed.
+func (p StaffPanel) content(state StaffState) string {
+	return "Vanish: " + onOff(state.Vanished) + "§r\nStaff mode: " + onOff(state.StaffMode) + "§r"
+}
+
+// settings returns the Custom form used to change the state of the staff member with the key passed.
+func (p StaffPanel) settings(submitter form.Submitter, key string) *Custom {
+	state, _ := p.Store.State(key)
+	return &Custom{
+		Title: p.title(),
+		Elements: []Element{
+			Toggle{Text: "Vanish", Default: state.Vanished, Submit: func(enabled bool) {
+				state.Vanished = enabled
+			}},
+			Toggle{Text: "Staff mode", Default: state.StaffMode, Submit: func(enabled bool) {
+				state.StaffMode = enabled
+			}},
+		},
+		Submit: func(closed bool, _ []any) {
+			if !closed {
+				p.Store.SetState(key, state)
+				if p.Apply != nil {
+					p.Apply(submitter, state)
+				}
+			}
+			p.Send(submitter, key)
+		},
+	}
+}
+
+// onOff formats the boolean passed as a colored 'On' or 'Off'.
+func onOff(b bool) string {
+	if b {
+		return "§aOn"
+	}
+	return "§cOff"
+}
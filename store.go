@@ -0,0 +1,147 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"sort"
+	"strings"
+)
+
+// Package is a package sold in a Store, such as a rank or a crate key.
+type Package struct {
+	// ID is the ID of the package, used by the StoreProvider to check out the package.
+	ID string
+	// Name is the name of the package. It may contain Minecraft formatting codes.
+	Name string
+	// Description is the description of the package displayed in its detail view. It may contain Minecraft formatting
+	// codes.
+	Description string
+	// Category is the category of the package. Packages without category are listed under 'Other'.
+	Category string
+	// Price is the formatted price of the package, such as '$4.99'.
+	Price string
+	// Image is the path or URL to the image displayed on the button of the package. It may be empty.
+	Image string
+}
+
+// StoreProvider provides the packages sold in a Store and hands off purchases to an external checkout, such as a web
+// store.
+type StoreProvider interface {
+	// Packages returns the packages sold in the store.
+	Packages() []Package
+	// Checkout starts the checkout of the package passed for the player passed. It returns the URL of the checkout and
+	// a short code that the player enters there to link the purchase to its account. If it returns an error, the
+	// error is displayed to the player.
+	Checkout(submitter form.Submitter, p Package) (url, code string, err error)
+}
+
+// Store represents the menus of a donation store: Packages are listed by category with their prices, and every
+// package has a detail view with a button to buy it. Because forms cannot open links, buying a package displays the
+// URL of the checkout and a code to enter there. Once the purchase is completed, Complete may be called to thank the
+// player.
+type Store struct {
+	// Title is the title of the forms.
+	Title string
+	// Provider provides the packages and handles checkouts.
+	Provider StoreProvider
+	// Thanks is the message displayed to a player once a purchase was completed. The '{package}' placeholder is
+	// replaced with the name of the package. If empty, a default message is displayed.
+	Thanks string
+}
+
+// Send sends the category menu of the store to the submitter passed.
+func (s Store) Send(submitter form.Submitter) {
+	submitter.SendForm(s.categories(submitter))
+}
+
+// Complete sends a modal thanking the submitter passed for purchasing the package passed. It should be called when the
+// purchase of a player is detected as completed, for example by a webhook of the web store.
+func (s Store) Complete(submitter form.Submitter, p Package) {
+	thanks := s.Thanks
+	if thanks == "" {
+		thanks = "Thank you for purchasing {package}§r! Your support keeps the server running."
+	}
+	submitter.SendForm(&Modal{
+		Title:   s.Title,
+		Content: strings.ReplaceAll(thanks, "{package}", p.Name),
+		Button1: Button{Text: "Close"},
+		Button2: Button{Text: "Visit store", Submit: func() {
+			s.Send(submitter)
+		}},
+	})
+}
+
+// categories returns the menu listing the categories of the packages.
+func (s Store) categories(submitter form.Submitter) *Menu {
+	packages := s.Provider.Packages()
+	var categories []string
+	seen := make(map[string]bool)
+	for _, p := range packages {
+		if category := packageCategory(p); !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	m := NewMenu(s.Title, "Select a category.")
+	for _, category := range categories {
+		category := category
+		m.AddButtons(Button{Text: category, Submit: func() {
+			submitter.SendForm(s.category(submitter, category))
+		}})
+	}
+	return m
+}
+
+// category returns the menu listing the packages in the category passed.
+func (s Store) category(submitter form.Submitter, category string) *Menu {
+	m := NewMenu(s.Title, category)
+	for _, p := range s.Provider.Packages() {
+		if packageCategory(p) != category {
+			continue
+		}
+		p := p
+		m.AddButtons(Button{Text: fmt.Sprintf("%v\n§2%v", p.Name, p.Price), Image: p.Image, Submit: func() {
+			submitter.SendForm(s.detail(submitter, p))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		s.Send(submitter)
+	}})
+	return m
+}
+
+// detail returns the menu displaying the package passed.
+func (s Store) detail(submitter form.Submitter, p Package) *Menu {
+	content := fmt.Sprintf("%v§r\nPrice: §2%v§r\n\n%v", p.Name, p.Price, p.Description)
+	return NewMenu(s.Title, content,
+		Button{Text: "Buy", Submit: func() {
+			submitter.SendForm(s.checkout(submitter, p))
+		}},
+		Button{Text: "Back", Submit: func() {
+			submitter.SendForm(s.category(submitter, packageCategory(p)))
+		}},
+	)
+}
+
+// checkout starts the checkout of the package passed and returns the menu displaying the URL and code of the
+// checkout.
+func (s Store) checkout(submitter form.Submitter, p Package) *Menu {
+	url, code, err := s.Provider.Checkout(submitter, p)
+	if err != nil {
+		d := s.detail(submitter, p)
+		d.Content += "\n\n§c" + err.Error()
+		return d
+	}
+	content := fmt.Sprintf("To buy %v§r, visit:\n§b%v§r\n\nand enter the code §l%v§r at checkout.", p.Name, url, code)
+	return NewMenu(s.Title, content, Button{Text: "Done"})
+}
+
+// packageCategory returns the category under which the package passed is listed.
+func packageCategory(p Package) string {
+	if p.Category == "" {
+		return "Other"
+	}
+	return p.Category
+}
@@ -0,0 +1,107 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// TagsEditor represents a form used to edit a list of tags, such as the lore of an item or the keywords of a shop. The
+// current tags are shown as buttons that remove the tag when clicked, followed by a button to add a new tag and a
+// button to finish editing. The editor is sent to the player again after every change, until the player presses the
+// done button or closes the form.
+type TagsEditor struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Tags holds the tags currently in the editor. It is updated every time the player adds or removes a tag.
+	Tags []string
+	// MaxTags is the maximum amount of tags that may be added. If zero, any amount of tags may be added.
+	MaxTags int
+	// Validate is called with every tag the player tries to add. If it returns an error, the tag is not added and the
+	// error is displayed to the player. Validate may be nil, in which case any non-empty tag that is not yet present
+	// may be added.
+	Validate func(tag string) error
+	// Submit is called with the final tags when the player presses the done button or closes the form.
+	Submit func(closed bool, tags []string)
+
+	err string
+}
+
+// MarshalJSON ...
+func (editor *TagsEditor) MarshalJSON() ([]byte, error) {
+	return editor.menu(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (editor *TagsEditor) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return editor.menu(submitter).SubmitJSON(data, submitter)
+}
+
+// menu returns the Menu that the editor currently consists of. Clicking its buttons updates the editor and sends it
+// to the submitter passed again.
+func (editor *TagsEditor) menu(submitter form.Submitter) *Menu {
+	content := editor.Content
+	if editor.err != "" {
+		content = strings.TrimSpace(content + "\n\n§c" + editor.err)
+	}
+	m := &Menu{Title: editor.Title, Content: content, Submit: func(closed bool) {
+		if closed && editor.Submit != nil {
+			editor.Submit(true, editor.Tags)
+		}
+	}}
+	for i, tag := range editor.Tags {
+		i := i
+		m.AddButtons(Button{Text: tag + "\n§cClick to remove", Submit: func() {
+			editor.err = ""
+			editor.Tags = append(editor.Tags[:i:i], editor.Tags[i+1:]...)
+			submitter.SendForm(editor)
+		}})
+	}
+	if editor.MaxTags == 0 || len(editor.Tags) < editor.MaxTags {
+		m.AddButtons(Button{Text: "Add tag", Submit: func() {
+			submitter.SendForm(editor.input(submitter))
+		}})
+	}
+	m.AddButtons(Button{Text: "Done", Submit: func() {
+		if editor.Submit != nil {
+			editor.Submit(false, editor.Tags)
+		}
+	}})
+	return m
+}
+
+// input returns the Custom form used to add a new tag to the editor. The editor is sent to the submitter passed
+// again once the form is submitted or closed.
+func (editor *TagsEditor) input(submitter form.Submitter) *Custom {
+	return &Custom{Title: editor.Title, Elements: []Element{Input{Text: "Tag", Submit: func(text string) {
+		editor.err = ""
+		if err := editor.add(strings.TrimSpace(text)); err != nil {
+			editor.err = err.Error()
+		}
+	}}}, Submit: func(bool, []any) {
+		submitter.SendForm(editor)
+	}}
+}
+
+// add validates the tag passed and adds it to the editor.
+func (editor *TagsEditor) add(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag may not be empty")
+	} else if editor.MaxTags != 0 && len(editor.Tags) >= editor.MaxTags {
+		return fmt.Errorf("no more than %v tags may be added", editor.MaxTags)
+	}
+	for _, t := range editor.Tags {
+		if t == tag {
+			return fmt.Errorf("tag %v was already added", tag)
+		}
+	}
+	if editor.Validate != nil {
+		if err := editor.Validate(tag); err != nil {
+			return err
+		}
+	}
+	editor.Tags = append(editor.Tags[:len(editor.Tags):len(editor.Tags)], tag)
+	return nil
+}
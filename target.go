@@ -0,0 +1,99 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+)
+
+// BulkAction represents an admin form that is filled out once and applied to multiple targets, such as punishing or
+// changing the settings of a group of players at once. The form consists of a MultiSelect of the targets, followed by
+// the Elements of the action. Once submitted, Apply is called for every target selected, after which a summary of the
+// targets for which the action succeeded or failed is sent to the submitter.
+type BulkAction[T any] struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Targets holds the targets that may be selected.
+	Targets []T
+	// Name returns the name of a target, displayed as the text of its toggle and in the summary. If nil, the target
+	// is formatted using fmt.Sprint.
+	Name func(target T) string
+	// Elements holds the elements of the action, filled out once for all targets. The values submitted for these
+	// elements should be stored by their Submit functions, so that they can be used by Apply.
+	Elements []Element
+	// Apply is called for every target selected once the form is submitted, after the Submit functions of the
+	// Elements. If it returns an error, the action failed for the target, and the error is displayed in the summary.
+	Apply func(target T) error
+	// Done is called with the results of the action for every target selected, in the same order as the Targets,
+	// before the summary is sent. Done may be nil.
+	Done func(results []TargetResult[T])
+}
+
+// TargetResult is the result of a BulkAction for a single target.
+type TargetResult[T any] struct {
+	// Target is the target that the action was applied to.
+	Target T
+	// Err is the error returned by Apply for the target, if any.
+	Err error
+}
+
+// MarshalJSON ...
+func (b *BulkAction[T]) MarshalJSON() ([]byte, error) {
+	return b.custom(nil).MarshalJSON()
+}
+
+// SubmitJSON ...
+func (b *BulkAction[T]) SubmitJSON(data []byte, submitter form.Submitter) error {
+	return b.custom(submitter).SubmitJSON(data, submitter)
+}
+
+// custom returns the Custom form that the action consists of. Submitting it applies the action and sends the summary
+// to the submitter passed.
+func (b *BulkAction[T]) custom(submitter form.Submitter) *Custom {
+	names := make([]string, len(b.Targets))
+	for i, target := range b.Targets {
+		names[i] = b.name(target)
+	}
+	var selected []int
+	elements := append([]Element{MultiSelect{Text: "Targets", Options: names, MinSelected: 1, Submit: func(s []int) {
+		selected = s
+	}}}, b.Elements...)
+	return &Custom{Title: b.Title, Elements: elements, Submit: func(closed bool, _ []any) {
+		if closed {
+			return
+		}
+		results := make([]TargetResult[T], 0, len(selected))
+		for _, i := range selected {
+			results = append(results, TargetResult[T]{Target: b.Targets[i], Err: b.Apply(b.Targets[i])})
+		}
+		if b.Done != nil {
+			b.Done(results)
+		}
+		submitter.SendForm(b.summary(results))
+	}}
+}
+
+// name returns the name of the target passed using Name, or formats the target using fmt.Sprint if Name is nil.
+func (b *BulkAction[T]) name(target T) string {
+	if b.Name == nil {
+		return fmt.Sprint(target)
+	}
+	return b.Name(target)
+}
+
+// summary returns the Menu displaying the results passed.
+func (b *BulkAction[T]) summary(results []TargetResult[T]) *Menu {
+	var failed int
+	lines := make([]string, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			failed++
+			lines[i] = fmt.Sprintf("§c✘ %v: %v§r", b.name(result.Target), result.Err)
+			continue
+		}
+		lines[i] = fmt.Sprintf("§a✔ %v§r", b.name(result.Target))
+	}
+	content := fmt.Sprintf("Succeeded for %v of %v targets.\n\n%v", len(results)-failed, len(results),
+		strings.Join(lines, "\n"))
+	return NewMenu(b.Title, content, Button{Text: "Done"})
+}
@@ -0,0 +1,85 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"regexp"
+)
+
+// placeholder matches the placeholders in templates, such as '{kills}'.
+var placeholder = regexp.MustCompile(`{([a-zA-Z0-9_.]+)}`)
+
+// Interpolate replaces every placeholder in the template passed, such as '{kills}', with the value of its key in the
+// data passed, formatted using fmt. Placeholders of which the key is not in the data are left as is.
+func Interpolate(template string, data map[string]any) string {
+	if len(data) == 0 {
+		return template
+	}
+	return placeholder.ReplaceAllStringFunc(template, func(s string) string {
+		if v, ok := data[s[1:len(s)-1]]; ok {
+			return fmt.Sprint(v)
+		}
+		return s
+	})
+}
+
+// TemplateLabel represents a Label of which the text is a template, such as 'Kills: {kills}'. The placeholders in the
+// template are replaced with the values in Data, and with those returned by PlayerData for the player that the form is
+// sent to, so that a single form may display the stats of every player viewing it. PlayerData is only used when
+// the form is sent using Menu.For or Custom.For.
+type TemplateLabel struct {
+	// Text is the template of the text held by the label. The text may contain Minecraft formatting codes.
+	Text string
+	// Data holds the values of placeholders shared by all players.
+	Data map[string]any
+	// PlayerData returns the values of placeholders for the submitter passed. Values returned override those in
+	// Data. PlayerData may be nil.
+	PlayerData func(submitter form.Submitter) map[string]any
+}
+
+// Resolve returns the Label with the template of the label resolved for the submitter passed. If the submitter is nil,
+// only the values in Data are used.
+func (t TemplateLabel) Resolve(submitter form.Submitter) Label {
+	data := make(map[string]any, len(t.Data))
+	for k, v := range t.Data {
+		data[k] = v
+	}
+	if submitter != nil && t.PlayerData != nil {
+		for k, v := range t.PlayerData(submitter) {
+			data[k] = v
+		}
+	}
+	return Label{Text: Interpolate(t.Text, data)}
+}
+
+// MarshalJSON ...
+func (t TemplateLabel) MarshalJSON() ([]byte, error) {
+	return t.Resolve(nil).MarshalJSON()
+}
+
+// SubmitValue ...
+func (TemplateLabel) SubmitValue(any) error {
+	return nil
+}
+
+// Clickable ...
+func (TemplateLabel) Clickable() bool {
+	return false
+}
+
+// Click ...
+func (TemplateLabel) Click() {}
+
+// For returns a copy of the form for the submitter passed, with the templates of all TemplateLabels resolved for the
+// submitter. The form returned should be sent to that submitter only.
+func (form *Custom) For(submitter form.Submitter) *Custom {
+	c := *form
+	c.Elements = make([]Element, len(form.Elements))
+	for i, element := range form.Elements {
+		if t, ok := element.(TemplateLabel); ok {
+			element = t.Resolve(submitter)
+		}
+		c.Elements[i] = element
+	}
+	return &c
+}
@@ -0,0 +1,104 @@
+package form
+
+import "sort"
+
+// Paths of textures in the local assets of the game that are commonly used as Image of a Button.
+const (
+	TextureGrassBlock       = "textures/blocks/grass_side_carried"
+	TextureDirt             = "textures/blocks/dirt"
+	TextureStone            = "textures/blocks/stone"
+	TextureCobblestone      = "textures/blocks/cobblestone"
+	TextureBedrock          = "textures/blocks/bedrock"
+	TextureBarrier          = "textures/blocks/barrier"
+	TextureChest            = "textures/blocks/chest_front"
+	TextureCraftingTable    = "textures/blocks/crafting_table_front"
+	TextureTNT              = "textures/blocks/tnt_side"
+	TextureDiamond          = "textures/items/diamond"
+	TextureEmerald          = "textures/items/emerald"
+	TextureGoldIngot        = "textures/items/gold_ingot"
+	TextureIronIngot        = "textures/items/iron_ingot"
+	TextureRedstone         = "textures/items/redstone_dust"
+	TextureNetherStar       = "textures/items/nether_star"
+	TextureDiamondSword     = "textures/items/diamond_sword"
+	TextureIronSword        = "textures/items/iron_sword"
+	TextureBow              = "textures/items/bow_standby"
+	TextureApple            = "textures/items/apple"
+	TextureBook             = "textures/items/book_normal"
+	TextureWritableBook     = "textures/items/book_writable"
+	TexturePaper            = "textures/items/paper"
+	TextureMap              = "textures/items/map_empty"
+	TextureCompass          = "textures/items/compass_item"
+	TextureClock            = "textures/items/clock_item"
+	TextureEnderPearl       = "textures/items/ender_pearl"
+	TextureExperienceBottle = "textures/items/experience_bottle"
+	TextureBed              = "textures/items/bed_red"
+	TextureConfirm          = "textures/ui/confirm"
+	TextureCancel           = "textures/ui/cancel"
+	TextureRefresh          = "textures/ui/refresh_light"
+	TextureSettings         = "textures/ui/gear"
+	TextureSearch           = "textures/ui/magnifyingGlass"
+	TextureTrash            = "textures/ui/icon_trash"
+	TextureFriends          = "textures/ui/FriendsIcon"
+	TextureSteve            = "textures/ui/icon_steve"
+	TextureOperator         = "textures/ui/permissions_op_crown"
+	TextureError            = "textures/ui/ErrorGlyph"
+)
+
+// textures maps the names accepted by Texture to the paths of their textures.
+var textures = map[string]string{
+	"grass_block":       TextureGrassBlock,
+	"dirt":              TextureDirt,
+	"stone":             TextureStone,
+	"cobblestone":       TextureCobblestone,
+	"bedrock":           TextureBedrock,
+	"barrier":           TextureBarrier,
+	"chest":             TextureChest,
+	"crafting_table":    TextureCraftingTable,
+	"tnt":               TextureTNT,
+	"diamond":           TextureDiamond,
+	"emerald":           TextureEmerald,
+	"gold_ingot":        TextureGoldIngot,
+	"iron_ingot":        TextureIronIngot,
+	"redstone":          TextureRedstone,
+	"nether_star":       TextureNetherStar,
+	"diamond_sword":     TextureDiamondSword,
+	"iron_sword":        TextureIronSword,
+	"bow":               TextureBow,
+	"apple":             TextureApple,
+	"book":              TextureBook,
+	"writable_book":     TextureWritableBook,
+	"paper":             TexturePaper,
+	"map":               TextureMap,
+	"compass":           TextureCompass,
+	"clock":             TextureClock,
+	"ender_pearl":       TextureEnderPearl,
+	"experience_bottle": TextureExperienceBottle,
+	"bed":               TextureBed,
+	"confirm":           TextureConfirm,
+	"cancel":            TextureCancel,
+	"refresh":           TextureRefresh,
+	"settings":          TextureSettings,
+	"search":            TextureSearch,
+	"trash":             TextureTrash,
+	"friends":           TextureFriends,
+	"steve":             TextureSteve,
+	"operator":          TextureOperator,
+	"error":             TextureError,
+}
+
+// Texture returns the path of the texture with the name passed, such as 'grass_block' or 'diamond', for use as Image
+// of a Button. If no texture with the name exists, false is returned.
+func Texture(name string) (string, bool) {
+	path, ok := textures[name]
+	return path, ok
+}
+
+// TextureNames returns the names of all textures accepted by Texture, sorted alphabetically.
+func TextureNames() []string {
+	names := make([]string, 0, len(textures))
+	for name := range textures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,226 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"strings"
+	"time"
+)
+
+// TicketStatus is the status of a Ticket.
+type TicketStatus int
+
+const (
+	// TicketOpen is the status of a ticket that was not yet handled by staff.
+	TicketOpen TicketStatus = iota
+	// TicketInProgress is the status of a ticket that is being handled by staff.
+	TicketInProgress
+	// TicketResolved is the status of a ticket that was resolved.
+	TicketResolved
+	// TicketClosed is the status of a ticket that was closed without being resolved.
+	TicketClosed
+)
+
+// String ...
+func (s TicketStatus) String() string {
+	switch s {
+	case TicketOpen:
+		return "Open"
+	case TicketInProgress:
+		return "In progress"
+	case TicketResolved:
+		return "Resolved"
+	case TicketClosed:
+		return "Closed"
+	}
+	return "Unknown"
+}
+
+// badge returns the status formatted as a colored badge.
+func (s TicketStatus) badge() string {
+	color := "§7"
+	switch s {
+	case TicketOpen:
+		color = "§e"
+	case TicketInProgress:
+		color = "§b"
+	case TicketResolved:
+		color = "§a"
+	}
+	return color + "[" + s.String() + "]§r"
+}
+
+// TicketComment is a comment on a Ticket, left either by the player that submitted it or by staff.
+type TicketComment struct {
+	// Author is the name of the author of the comment.
+	Author string
+	// Text is the text of the comment.
+	Text string
+	// Time is the time at which the comment was left.
+	Time time.Time
+}
+
+// Ticket is a report or support ticket submitted by a player.
+type Ticket struct {
+	// ID is the ID of the ticket.
+	ID string
+	// Subject is the subject of the ticket, such as the name of the player reported.
+	Subject string
+	// Status is the current status of the ticket.
+	Status TicketStatus
+	// Comments holds the comments on the ticket, from oldest to newest.
+	Comments []TicketComment
+	// Updated is the time at which the ticket was last updated by staff.
+	Updated time.Time
+	// Unread specifies if the ticket was updated by staff since the player last viewed it.
+	Unread bool
+}
+
+// TicketProvider provides the tickets submitted by players. Players are identified by a key, such as their UUID.
+type TicketProvider interface {
+	// Tickets returns the tickets submitted by the player with the key passed, from newest to oldest.
+	Tickets(key string) []Ticket
+	// Comment adds a comment with the text passed to the ticket with the ID passed, submitted by the player with the
+	// key passed. If it returns an error, the error is displayed to the player.
+	Comment(key, id, text string) error
+	// MarkRead marks the ticket with the ID passed as read by the player with the key passed.
+	MarkRead(key, id string)
+}
+
+// MyTickets represents the menus in which players can follow the reports and tickets they submitted: Every ticket is
+// listed with a badge of its status, and players may view the comments on a ticket and add comments of their own.
+// Updates to tickets are delivered through Reminders, using the ReminderProvider returned by Reminders.
+type MyTickets struct {
+	// Title is the title of the forms. If empty, 'My tickets' is used.
+	Title string
+	// Provider provides the tickets and stores comments.
+	Provider TicketProvider
+	// MaxCommentLength is the maximum length of a comment. If zero, comments of up to 256 characters may be left.
+	MaxCommentLength int
+}
+
+// Send sends the ticket list to the submitter passed, which is the player with the key passed.
+func (t MyTickets) Send(submitter form.Submitter, key string) {
+	submitter.SendForm(t.list(submitter, key))
+}
+
+// Reminders returns a ReminderProvider that provides a reminder for every unread ticket of a player, which opens the
+// ticket. It should be added to the Providers of a Reminders to notify players of updates to their tickets.
+func (t MyTickets) Reminders() ReminderProvider {
+	return func(key string) []Reminder {
+		var reminders []Reminder
+		for _, ticket := range t.Provider.Tickets(key) {
+			if !ticket.Unread {
+				continue
+			}
+			ticket := ticket
+			reminders = append(reminders, Reminder{
+				// The time of the update is part of the ID, so that new updates are reminded of even if an earlier
+				// update was dismissed.
+				ID:    fmt.Sprintf("ticket:%v:%v", ticket.ID, ticket.Updated.Unix()),
+				Text:  fmt.Sprintf("Ticket #%v was updated\n%v", ticket.ID, ticket.Status.badge()),
+				Image: TextureBook,
+				Open: func(submitter form.Submitter) {
+					submitter.SendForm(t.ticket(submitter, key, ticket.ID, ""))
+				},
+			})
+		}
+		return reminders
+	}
+}
+
+// title returns the title of the forms.
+func (t MyTickets) title() string {
+	if t.Title == "" {
+		return "My tickets"
+	}
+	return t.Title
+}
+
+// list returns the menu listing the tickets of the player with the key passed.
+func (t MyTickets) list(submitter form.Submitter, key string) *Menu {
+	tickets := t.Provider.Tickets(key)
+	m := NewMenu(t.title(), fmt.Sprintf("You submitted %v tickets.", len(tickets)))
+	if len(tickets) == 0 {
+		m.Content = "You have not submitted any tickets."
+	}
+	for _, ticket := range tickets {
+		id := ticket.ID
+		text := fmt.Sprintf("#%v %v\n%v", ticket.ID, ticket.Subject, ticket.Status.badge())
+		if ticket.Unread {
+			text += " §6New update"
+		}
+		m.AddButtons(Button{Text: text, Submit: func() {
+			submitter.SendForm(t.ticket(submitter, key, id, ""))
+		}})
+	}
+	m.AddButtons(Button{Text: "Close"})
+	return m
+}
+
+// ticket returns the menu displaying the ticket with the ID passed, with the message passed displayed below it. The
+// ticket is marked as read.
+func (t MyTickets) ticket(submitter form.Submitter, key, id, msg string) *Menu {
+	var ticket Ticket
+	var found bool
+	for _, tk := range t.Provider.Tickets(key) {
+		if tk.ID == id {
+			ticket, found = tk, true
+			break
+		}
+	}
+	if !found {
+		m := t.list(submitter, key)
+		m.Content += "\n\n§cThis ticket no longer exists."
+		return m
+	}
+	t.Provider.MarkRead(key, id)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%v %v§r %v\n", ticket.ID, ticket.Subject, ticket.Status.badge())
+	for _, c := range ticket.Comments {
+		fmt.Fprintf(&b, "\n§7%v - %v§r\n%v\n", c.Author, c.Time.Format("2006-01-02 15:04"), c.Text)
+	}
+	if msg != "" {
+		b.WriteString("\n" + msg)
+	}
+	m := NewMenu(t.title(), strings.TrimSpace(b.String()))
+	if ticket.Status == TicketOpen || ticket.Status == TicketInProgress {
+		m.AddButtons(Button{Text: "Add comment", Submit: func() {
+			submitter.SendForm(t.comment(submitter, key, id))
+		}})
+	}
+	m.AddButtons(Button{Text: "Back", Submit: func() {
+		t.Send(submitter, key)
+	}})
+	return m
+}
+
+// comment returns the Custom form used to add a comment to the ticket with the ID passed.
+func (t MyTickets) comment(submitter form.Submitter, key, id string) *Custom {
+	max := t.MaxCommentLength
+	if max <= 0 {
+		max = 256
+	}
+	var comment string
+	return &Custom{
+		Title: t.title(),
+		Elements: []Element{Input{Text: "Comment", MaxLength: max, Truncate: true, Submit: func(text string) {
+			comment = strings.TrimSpace(text)
+		}}},
+		Submit: func(closed bool, _ []any) {
+			switch {
+			case closed:
+				submitter.SendForm(t.ticket(submitter, key, id, ""))
+			case comment == "":
+				submitter.SendForm(t.ticket(submitter, key, id, "§cYour comment was empty."))
+			default:
+				if err := t.Provider.Comment(key, id, comment); err != nil {
+					submitter.SendForm(t.ticket(submitter, key, id, "§c"+err.Error()))
+					return
+				}
+				submitter.SendForm(t.ticket(submitter, key, id, "§aYour comment was added."))
+			}
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package form
+
+import (
+	"github.com/df-mc/dragonfly/server/player/form"
+	"reflect"
+	"sync"
+)
+
+// Tracker tracks the form that is currently open for every submitter, so that it may be closed or preempted by an
+// emergency form, such as a restart warning. Forms must be sent through the Tracker to be tracked. Submitters of which
+// the type is not comparable are not tracked. The zero value of a Tracker is ready to use. A Tracker is safe for
+// concurrent use.
+type Tracker struct {
+	// CloseClient is called by Close to close the form open on the client of the submitter passed, such as by sending
+	// a ClientBoundCloseForm packet on versions that support it. CloseClient may be nil, in which case the form remains
+	// open on the client, but its response is ignored.
+	CloseClient func(submitter form.Submitter)
+
+	mu   sync.Mutex
+	open map[form.Submitter]*openForm
+}
+
+// Send sends the form passed to the submitter passed and tracks it as its open form until it is submitted or closed.
+func (t *Tracker) Send(submitter form.Submitter, f form.Form) {
+	o := &openForm{Form: f, t: t}
+	if reflect.TypeOf(submitter).Comparable() {
+		t.mu.Lock()
+		if t.open == nil {
+			t.open = make(map[form.Submitter]*openForm)
+		}
+		t.open[submitter] = o
+		t.mu.Unlock()
+	}
+	submitter.SendForm(o)
+}
+
+// Open returns the form that is currently open for the submitter passed. If no form sent through the Tracker is
+// open, false is returned.
+func (t *Tracker) Open(submitter form.Submitter) (form.Form, bool) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.open[submitter]
+	if !ok {
+		return nil, false
+	}
+	return o.Form, true
+}
+
+// Preempt sends the emergency modal passed to the submitter passed, preempting the form currently open for it. Once
+// the modal is submitted or closed, the preempted form is sent again, so that the player may continue where it left
+// off. Preempt returns true if a form was preempted.
+//
+// If CloseClient is set, the form open on the client is closed before the modal is sent, so that the modal is
+// displayed right away, even if that form was not sent through the Tracker. Otherwise, the modal is displayed as soon as the player closes the form currently open.
+// Closing the preempted form is not passed to it, as the form is sent again after the modal. If the player submits
+// the preempted form before it is closed, the submission is handled as usual and the form is not sent again.
+func (t *Tracker) Preempt(submitter form.Submitter, emergency *Modal) bool {
+	var preempted *openForm
+	if reflect.TypeOf(submitter).Comparable() {
+		t.mu.Lock()
+		if preempted = t.open[submitter]; preempted != nil {
+			preempted.preempted = true
+		}
+		delete(t.open, submitter)
+		t.mu.Unlock()
+	}
+	m := *emergency
+	m.Submit = func(closed bool) {
+		if emergency.Submit != nil {
+			emergency.Submit(closed)
+		}
+		if preempted != nil && preempted.resend() {
+			t.Send(submitter, preempted.Form)
+		}
+	}
+	if t.CloseClient != nil {
+		t.CloseClient(submitter)
+	}
+	submitter.SendForm(&m)
+	return preempted != nil
+}
+
+// Close closes the form that is currently open for the submitter passed, such as when a timed menu expires or the
+// player is logged out. The form is submitted as if the player closed it, so that its Submit is called with closed
+// set to true, and the response of the client to the form, if any, is ignored. Close returns false if no form sent
+// through the Tracker was open.
+func (t *Tracker) Close(submitter form.Submitter) (bool, error) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return false, nil
+	}
+	t.mu.Lock()
+	o, ok := t.open[submitter]
+	delete(t.open, submitter)
+	t.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	if t.CloseClient != nil {
+		t.CloseClient(submitter)
+	}
+	o.mu.Lock()
+	if o.answered {
+		o.mu.Unlock()
+		return false, nil
+	}
+	o.answered = true
+	o.mu.Unlock()
+	return true, o.Form.SubmitJSON(nil, submitter)
+}
+
+// remove stops tracking the form passed as the open form of the submitter passed, if it is still its open form.
+func (t *Tracker) remove(submitter form.Submitter, o *openForm) {
+	if !reflect.TypeOf(submitter).Comparable() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.open[submitter] == o {
+		delete(t.open, submitter)
+	}
+}
+
+// openForm is a form sent through a Tracker.
+type openForm struct {
+	form.Form
+	t *Tracker
+
+	mu        sync.Mutex
+	preempted bool
+	answered  bool
+}
+
+// SubmitJSON ...
+func (f *openForm) SubmitJSON(data []byte, submitter form.Submitter) error {
+	f.t.remove(submitter, f)
+	f.t.mu.Lock()
+	preempted := f.preempted
+	f.t.mu.Unlock()
+	if preempted && data == nil {
+		// The form was closed so that the emergency form could be displayed. It is sent again afterwards.
+		return nil
+	}
+	f.mu.Lock()
+	if f.answered {
+		// The form was already closed using Close, so the response is no longer relevant.
+		f.mu.Unlock()
+		return nil
+	}
+	f.answered = true
+	f.mu.Unlock()
+	return f.Form.SubmitJSON(data, submitter)
+}
+
+// resend checks if a preempted form should be sent again, which is the case if it was not submitted by the player.
+func (f *openForm) resend() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.answered
+}
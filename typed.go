@@ -0,0 +1,131 @@
+package form
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Option is an option of a TypedDropdown. It holds the text displayed for the option and the value it represents.
+type Option[T any] struct {
+	// Text is the text displayed for the option. The text may contain Minecraft formatting codes.
+	Text string
+	// Value is the value passed to the dropdown's Submit when the option is selected.
+	Value T
+}
+
+// TypedDropdown represents a dropdown of which the options each hold a value of the type T. Unlike a Dropdown, it
+// submits the value of the option selected, rather than its index and text.
+type TypedDropdown[T any] struct {
+	// Text is the text displayed over the dropdown element. The text may contain Minecraft formatting codes.
+	Text string
+	// Options holds a list of options that a Submitter may select. The order of these options is retained
+	// when shown to the submitter of the form.
+	Options []Option[T]
+	// DefaultIndex is the index in the Options slice that is used as default. When sent to a Submitter, the
+	// value at this index in the Options slice will be selected.
+	DefaultIndex int
+	// Submit is called with the value of the option selected by the player whenever they submit the form. If the form
+	// is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(value T)
+}
+
+// MarshalJSON ...
+func (d TypedDropdown[T]) MarshalJSON() ([]byte, error) {
+	return d.dropdown().MarshalJSON()
+}
+
+// SubmitValue ...
+func (d TypedDropdown[T]) SubmitValue(value any) error {
+	return d.dropdown().SubmitValue(value)
+}
+
+// dropdown returns the Dropdown that the TypedDropdown is sent as.
+func (d TypedDropdown[T]) dropdown() Dropdown {
+	options := make([]string, len(d.Options))
+	for i, option := range d.Options {
+		options[i] = option.Text
+	}
+	dropdown := Dropdown{Text: d.Text, Options: options, DefaultIndex: d.DefaultIndex}
+	if d.Submit != nil {
+		dropdown.Submit = func(index int, _ string) {
+			d.Submit(d.Options[index].Value)
+		}
+	}
+	return dropdown
+}
+
+// DropdownOf creates a TypedDropdown with an option for every value passed, displaying the value's String() as the
+// text of the option. The submit function passed is called with the value of the option selected and may be nil.
+func DropdownOf[T fmt.Stringer](text string, values []T, submit func(value T)) TypedDropdown[T] {
+	options := make([]Option[T], len(values))
+	for i, value := range values {
+		options[i] = Option[T]{Text: value.String(), Value: value}
+	}
+	return TypedDropdown[T]{Text: text, Options: options, Submit: submit}
+}
+
+// DropdownFromMap creates a TypedDropdown with an option for every entry of the map passed, displaying the key of the
+// entry as the text of the option. The options are sorted by their keys, so that the order of the options is the same
+// every time the dropdown is sent. The submit function passed is called with the value of the option selected and may
+// be nil.
+func DropdownFromMap[V any](text string, m map[string]V, submit func(value V)) TypedDropdown[V] {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	options := make([]Option[V], len(keys))
+	for i, key := range keys {
+		options[i] = Option[V]{Text: key, Value: m[key]}
+	}
+	return TypedDropdown[V]{Text: text, Options: options, Submit: submit}
+}
+
+// NumericStepSlider represents a step slider of which every step represents a number, such as a multiplier of 0.5x,
+// 1x or 2x. Unlike a StepSlider, it submits the number of the step selected, rather than its index and text.
+type NumericStepSlider struct {
+	// Text is the text displayed over the step slider element. The text may contain Minecraft formatting codes.
+	Text string
+	// Values holds the numbers of the steps that a Submitter may select, in order.
+	Values []float64
+	// Format formats a number into the label of its step. If nil, numbers are displayed in their shortest
+	// representation.
+	Format func(value float64) string
+	// Default is the number of the step selected by default. If none of the Values is equal to Default, the first step
+	// is selected.
+	Default float64
+	// Submit is called with the number of the step selected by the player whenever they submit the form. If the form
+	// is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(value float64)
+}
+
+// MarshalJSON ...
+func (s NumericStepSlider) MarshalJSON() ([]byte, error) {
+	return s.stepSlider().MarshalJSON()
+}
+
+// SubmitValue ...
+func (s NumericStepSlider) SubmitValue(value any) error {
+	return s.stepSlider().SubmitValue(value)
+}
+
+// stepSlider returns the StepSlider that the NumericStepSlider is sent as.
+func (s NumericStepSlider) stepSlider() StepSlider {
+	format := s.Format
+	if format == nil {
+		format = formatFloat
+	}
+	slider := StepSlider{Text: s.Text, Options: make([]string, len(s.Values))}
+	for i, value := range s.Values {
+		slider.Options[i] = format(value)
+		if value == s.Default {
+			slider.DefaultIndex = i
+		}
+	}
+	if s.Submit != nil {
+		slider.Submit = func(index int, _ string) {
+			s.Submit(s.Values[index])
+		}
+	}
+	return slider
+}
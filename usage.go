@@ -0,0 +1,87 @@
+package form
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"sort"
+	"sync"
+)
+
+// Usage tracks how often players click the buttons of menus and uses it to order the buttons of menus per player, with
+// the buttons a player uses most at the top. This speeds up navigating large menus, such as admin panels and shops.
+// Buttons are identified by their text without formatting codes. The zero value of a Usage is ready to use. A Usage is
+// safe for concurrent use.
+type Usage struct {
+	mu     sync.Mutex
+	clicks map[[2]string]map[string]uint64
+}
+
+// Adapt returns a copy of the menu passed with the ID passed for the player with the key passed, such as its UUID,
+// with its Buttons ordered by how often the player clicked them, most clicked first. The first pinned Buttons of the
+// menu keep their position at the top, and buttons clicked equally often keep their original order. Clicks on the
+// buttons of the menu returned are counted. The Elements of the menu are not reordered.
+func (u *Usage) Adapt(key, id string, m *Menu, pinned int) *Menu {
+	if pinned < 0 {
+		pinned = 0
+	} else if pinned > len(m.Buttons) {
+		pinned = len(m.Buttons)
+	}
+	counts := u.Clicks(key, id)
+
+	c := *m
+	c.Buttons = make([]Button, len(m.Buttons))
+	for i, b := range m.Buttons {
+		b := b
+		name := text.Clean(b.Text)
+		submit := b.Submit
+		b.Submit = func() {
+			u.click(key, id, name)
+			if submit != nil {
+				submit()
+			}
+		}
+		c.Buttons[i] = b
+	}
+	rest := c.Buttons[pinned:]
+	sort.SliceStable(rest, func(i, j int) bool {
+		return counts[text.Clean(rest[i].Text)] > counts[text.Clean(rest[j].Text)]
+	})
+	return &c
+}
+
+// Clicks returns the amount of times the player with the key passed clicked every button of the menu with the ID
+// passed, by the text of the buttons without formatting codes.
+func (u *Usage) Clicks(key, id string) map[string]uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	clicks := make(map[string]uint64, len(u.clicks[[2]string{key, id}]))
+	for name, n := range u.clicks[[2]string{key, id}] {
+		clicks[name] = n
+	}
+	return clicks
+}
+
+// Reset removes all clicks counted for the player with the key passed.
+func (u *Usage) Reset(key string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for k := range u.clicks {
+		if k[0] == key {
+			delete(u.clicks, k)
+		}
+	}
+}
+
+// click counts a click by the player with the key passed on the button with the name passed in the menu with the ID
+// passed.
+func (u *Usage) click(key, id, name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.clicks == nil {
+		u.clicks = make(map[[2]string]map[string]uint64)
+	}
+	k := [2]string{key, id}
+	if u.clicks[k] == nil {
+		u.clicks[k] = make(map[string]uint64)
+	}
+	u.clicks[k][name]++
+}
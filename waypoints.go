@@ -0,0 +1,127 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"time"
+)
+
+// Waypoint is a destination that a player may teleport to using Waypoints.
+type Waypoint struct {
+	// Name is the name of the waypoint displayed on its button. It may contain Minecraft formatting codes.
+	Name string
+	// Position is the position of the waypoint.
+	Position mgl64.Vec3
+	// Cost is the cost of teleporting to the waypoint. If zero, teleporting is free and does not need to be
+	// confirmed.
+	Cost int
+	// Image is the path or URL to the image displayed on the button of the waypoint. It may be empty.
+	Image string
+}
+
+// Waypoints represents a teleport menu listing destinations, such as warps or homes. The button of every waypoint
+// shows the distance to it, the cost of teleporting and the cooldown left, computed for the player every time the
+// menu is sent. Teleports that cost something must be confirmed by the player first.
+type Waypoints struct {
+	// Title is the title of the form that is displayed at the very top of the form.
+	Title string
+	// Content is the content that is displayed underneath the title and before any buttons.
+	Content string
+	// Waypoints holds the waypoints displayed in the menu.
+	Waypoints []Waypoint
+	// Position returns the current position of the player, used to display the distance to every waypoint. If nil,
+	// no distances are displayed.
+	Position func(submitter form.Submitter) mgl64.Vec3
+	// Cooldown returns the time left before the player may teleport to the waypoint passed. If nil, there are no
+	// cooldowns.
+	Cooldown func(submitter form.Submitter, w Waypoint) time.Duration
+	// Pay is called before teleporting to a waypoint with a non-zero Cost to take the cost from the player. If it
+	// returns an error, such as when the player cannot afford the teleport, the error is displayed to the player and
+	// the player is not teleported. Pay may be nil.
+	Pay func(submitter form.Submitter, w Waypoint) error
+	// Teleport is called to teleport the player to the waypoint passed. It may start a warmup instead of teleporting
+	// the player immediately, which may be cancelled if the player moves, and should start the cooldown of the
+	// waypoint.
+	Teleport func(submitter form.Submitter, w Waypoint)
+}
+
+// Send sends the menu to the submitter passed.
+func (wp Waypoints) Send(submitter form.Submitter) {
+	submitter.SendForm(wp.menu(submitter, ""))
+}
+
+// menu returns the Menu of the waypoints for the submitter passed, with the error passed displayed under the content.
+func (wp Waypoints) menu(submitter form.Submitter, err string) *Menu {
+	content := wp.Content
+	if err != "" {
+		content = strings.TrimSpace(content + "\n\n§c" + err)
+	}
+	m := NewMenu(wp.Title, content)
+	for _, w := range wp.Waypoints {
+		w := w
+		var details []string
+		if wp.Position != nil {
+			details = append(details, fmt.Sprintf("§7%.0fm", wp.Position(submitter).Sub(w.Position).Len()))
+		}
+		if w.Cost > 0 {
+			details = append(details, fmt.Sprintf("§6Cost: %v", w.Cost))
+		}
+		if left := wp.cooldown(submitter, w); left > 0 {
+			details = append(details, fmt.Sprintf("§c%v", left.Round(time.Second)))
+		}
+		text := w.Name
+		if len(details) > 0 {
+			text += "\n" + strings.Join(details, " §8| ")
+		}
+		m.AddButtons(Button{Text: text, Image: w.Image, Submit: func() {
+			wp.pick(submitter, w)
+		}})
+	}
+	return m
+}
+
+// pick is called when the submitter passed picks the waypoint passed.
+func (wp Waypoints) pick(submitter form.Submitter, w Waypoint) {
+	if left := wp.cooldown(submitter, w); left > 0 {
+		err := fmt.Sprintf("You must wait %v to teleport to %v.", left.Round(time.Second), w.Name)
+		submitter.SendForm(wp.menu(submitter, err))
+		return
+	}
+	if w.Cost <= 0 {
+		wp.teleport(submitter, w)
+		return
+	}
+	submitter.SendForm(&Modal{
+		Title:   wp.Title,
+		Content: fmt.Sprintf("Teleport to %v§r for %v?", w.Name, w.Cost),
+		Button1: Button{Text: "Teleport", Submit: func() {
+			if wp.Pay != nil {
+				if err := wp.Pay(submitter, w); err != nil {
+					submitter.SendForm(wp.menu(submitter, err.Error()))
+					return
+				}
+			}
+			wp.teleport(submitter, w)
+		}},
+		Button2: Button{Text: "Cancel", Submit: func() {
+			wp.Send(submitter)
+		}},
+	})
+}
+
+// teleport teleports the submitter passed to the waypoint passed.
+func (wp Waypoints) teleport(submitter form.Submitter, w Waypoint) {
+	if wp.Teleport != nil {
+		wp.Teleport(submitter, w)
+	}
+}
+
+// cooldown returns the cooldown left for the submitter passed for the waypoint passed.
+func (wp Waypoints) cooldown(submitter form.Submitter, w Waypoint) time.Duration {
+	if wp.Cooldown == nil {
+		return 0
+	}
+	return wp.Cooldown(submitter, w)
+}
@@ -0,0 +1,102 @@
+package form
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// WorldSelector represents a dropdown used to select one of a list of worlds supplied by the server, such as the
+// worlds that a player may teleport to. The worlds are obtained from the Worlds function every time the form is sent,
+// and the world selected is submitted rather than its name. Because the worlds are stored when the form is sent, a
+// WorldSelector must be added to a form as a pointer.
+type WorldSelector struct {
+	// Text is the text displayed over the dropdown element. The text may contain Minecraft formatting codes.
+	Text string
+	// Worlds returns the worlds that may be selected, in the order that they are displayed. It is called again when
+	// the form is submitted, to check if the world selected was not unloaded in the meantime.
+	Worlds func() []*world.World
+	// Name returns the name of a world displayed in the dropdown. If nil, the name of the world is displayed.
+	Name func(w *world.World) string
+	// Submit is called with the world selected whenever the form is submitted, if the world is still loaded. If the
+	// form is closed, this method is not called. This is always called before the Form's Submit.
+	Submit func(w *world.World)
+	// Unloaded is called with the name of the world selected if the world was unloaded before the form was submitted.
+	// If Unloaded is nil, submitting a world that was unloaded returns an error instead.
+	Unloaded func(name string)
+
+	mu     sync.Mutex
+	worlds []*world.World
+}
+
+// MarshalJSON ...
+func (s *WorldSelector) MarshalJSON() ([]byte, error) {
+	var worlds []*world.World
+	if s.Worlds != nil {
+		worlds = s.Worlds()
+	}
+	s.mu.Lock()
+	s.worlds = worlds
+	s.mu.Unlock()
+
+	options := make([]string, len(worlds))
+	for i, w := range worlds {
+		options[i] = s.name(w)
+	}
+	if len(options) == 0 {
+		options = []string{"No worlds"}
+	}
+	return Dropdown{Text: s.Text, Options: options}.MarshalJSON()
+}
+
+// SubmitValue ...
+func (s *WorldSelector) SubmitValue(value any) error {
+	if s.Submit == nil && s.Unloaded == nil {
+		return nil
+	}
+	s.mu.Lock()
+	worlds := s.worlds
+	s.mu.Unlock()
+	if len(worlds) == 0 {
+		return fmt.Errorf("world selector has no worlds to select")
+	}
+	var w *world.World
+	err := Dropdown{Options: make([]string, len(worlds)), Submit: func(i int, _ string) {
+		w = worlds[i]
+	}}.SubmitValue(value)
+	if err != nil {
+		return err
+	}
+	if !s.loaded(w) {
+		if s.Unloaded == nil {
+			return fmt.Errorf("world %v was unloaded", s.name(w))
+		}
+		s.Unloaded(s.name(w))
+		return nil
+	}
+	if s.Submit != nil {
+		s.Submit(w)
+	}
+	return nil
+}
+
+// loaded checks if the world passed is still returned by the Worlds function.
+func (s *WorldSelector) loaded(w *world.World) bool {
+	if s.Worlds == nil {
+		return false
+	}
+	for _, loaded := range s.Worlds() {
+		if loaded == w {
+			return true
+		}
+	}
+	return false
+}
+
+// name returns the name of the world passed displayed in the dropdown.
+func (s *WorldSelector) name(w *world.World) string {
+	if s.Name != nil {
+		return s.Name(w)
+	}
+	return w.Name()
+}